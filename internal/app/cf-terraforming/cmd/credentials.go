@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// tokenCommandTimeout bounds how long a `--token-command` helper may run for.
+// It is generous enough for helpers that prompt for a passphrase or touch.
+const tokenCommandTimeout = 2 * time.Minute
+
+// resolveAPIToken returns the API token from the first configured source:
+// `--token`, then `--token-file` and finally `--token-command`. An empty token
+// is returned when none of them are set.
+func resolveAPIToken(token, tokenFile, tokenCommand string) (string, error) {
+	if tokenFile != "" && tokenCommand != "" {
+		return "", errors.New("--token-file and --token-command are mutually exclusive")
+	}
+
+	switch {
+	case token != "":
+		return token, nil
+	case tokenFile != "":
+		return readTokenFile(tokenFile)
+	case tokenCommand != "":
+		return runTokenCommand(tokenCommand)
+	}
+	return "", nil
+}
+
+// readTokenFile reads the token from the first line of path.
+func readTokenFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		log.Warnf("token file %s is accessible by other users, consider restricting it with `chmod 600`", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token, _, _ := strings.Cut(string(data), "\n")
+	if token = strings.TrimSpace(token); token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// runTokenCommand runs command using the system shell, in the style of git
+// credential helpers, and returns the first line it prints. Anything the
+// helper writes to stderr is passed through so it can prompt the user.
+func runTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = os.Stderr
+	c.Stdin = os.Stdin
+
+	if err := c.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("token command timed out after %s", tokenCommandTimeout)
+		}
+		return "", fmt.Errorf("token command failed: %w", err)
+	}
+
+	token, _, _ := strings.Cut(stdout.String(), "\n")
+	if token = strings.TrimSpace(token); token == "" {
+		return "", errors.New("token command did not print a token")
+	}
+	return token, nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAPIToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0600))
+	emptyFile := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(emptyFile, []byte("\n"), 0600))
+
+	token, err := resolveAPIToken("flag-token", tokenFile, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "flag-token", token)
+
+	token, err = resolveAPIToken("", tokenFile, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "file-token", token)
+
+	_, err = resolveAPIToken("", emptyFile, "")
+	assert.Error(t, err)
+
+	_, err = resolveAPIToken("", filepath.Join(dir, "missing"), "")
+	assert.Error(t, err)
+
+	_, err = resolveAPIToken("", tokenFile, "echo command-token")
+	assert.EqualError(t, err, "--token-file and --token-command are mutually exclusive")
+
+	token, err = resolveAPIToken("", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", token)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("token command tests use a POSIX shell")
+	}
+
+	token, err = resolveAPIToken("", "", "printf 'command-token\\nignored\\n'")
+	assert.NoError(t, err)
+	assert.Equal(t, "command-token", token)
+
+	_, err = resolveAPIToken("", "", "exit 1")
+	assert.Error(t, err)
+
+	_, err = resolveAPIToken("", "", "true")
+	assert.EqualError(t, err, "token command did not print a token")
+}
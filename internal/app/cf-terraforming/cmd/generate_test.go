@@ -1,13 +1,13 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
 
 	cfv0 "github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/cloudflare-go/v4"
@@ -16,77 +16,20 @@ import (
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
 
-	"github.com/hashicorp/hcl/v2/hclwrite"
-
 	"github.com/spf13/viper"
 
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	// listOfString is an example representation of a key where the value is a
-	// list of string values.
-	//
-	//   resource "example" "example" {
-	//     attr = [ "b", "c", "d"]
-	//   }
-	listOfString = []interface{}{"b", "c", "d"}
-
-	// configBlockOfStrings is an example of where a key is a "block" assignment
-	// in HCL.
-	//
-	//   resource "example" "example" {
-	//     attr = {
-	//       c = "d"
-	//       e = "f"
-	//     }
-	//   }
-	configBlockOfStrings = map[string]interface{}{
-		"c": "d",
-		"e": "f",
-	}
-
 	cloudflareTestZoneID    = "0da42c8d2132a9ddaf714f9e7c920711"
 	cloudflareTestAccountID = "f037e56e89293a057740de681ac9abbe"
 )
 
-func TestGenerate_writeAttrLineV4(t *testing.T) {
-	multilineListOfStrings := heredoc.Doc(`
-		a = ["b", "c", "d"]
-	`)
-	multilineBlock := heredoc.Doc(`
-		a = {
-		  c = "d"
-		  e = "f"
-		}
-	`)
-	tests := map[string]struct {
-		key   string
-		value interface{}
-		want  string
-	}{
-		"value is string":           {key: "a", value: "b", want: fmt.Sprintf("a = %q\n", "b")},
-		"value is int":              {key: "a", value: 1, want: "a = 1\n"},
-		"value is float":            {key: "a", value: 1.0, want: "a = 1\n"},
-		"value is bool":             {key: "a", value: true, want: "a = true\n"},
-		"value is list of strings":  {key: "a", value: listOfString, want: multilineListOfStrings},
-		"value is block of strings": {key: "a", value: configBlockOfStrings, want: multilineBlock},
-		"value is nil":              {key: "a", value: nil, want: ""},
-	}
-
-	for name, tc := range tests {
-		f := hclwrite.NewEmptyFile()
-		t.Run(name, func(t *testing.T) {
-			writeAttrLine(tc.key, tc.value, "", f.Body())
-			assert.Equal(t, tc.want, string(f.Bytes()))
-		})
-	}
-}
-
 func TestGenerate_ResourceNotSupportedV4(t *testing.T) {
 	output, err := executeCommandC(rootCmd, "generate", "--resource-type", "notreal")
 	assert.Nil(t, err)
-	assert.Equal(t, `"notreal" is not yet supported for automatic generation`, output)
+	assert.Equal(t, "\"notreal\" is not yet supported for automatic generation\n", output)
 }
 
 func TestResourceGenerationV4(t *testing.T) {
@@ -178,11 +121,6 @@ func TestResourceGenerationV4(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			// Reset the environment variables used in test to ensure we don't
-			// have both present at once.
-			viper.Set("zone", "")
-			viper.Set("account", "")
-
 			var r *recorder.Recorder
 			var err error
 			if os.Getenv("OVERWRITE_VCR_CASSETTES") == "true" {
@@ -225,19 +163,18 @@ func TestResourceGenerationV4(t *testing.T) {
 				return nil
 			})
 
-			apiV0, _ = cfv0.New(viper.GetString("key"), viper.GetString("email"), cfv0.HTTPClient(
+			apiV0, _ := cfv0.New(viper.GetString("key"), viper.GetString("email"), cfv0.HTTPClient(
 				&http.Client{
 					Transport: r,
 				},
 			))
-			output := ""
+			args := []string{"generate", "--resource-type", tc.resourceType}
 			if tc.identiferType == "account" {
-				viper.Set("account", cloudflareTestAccountID)
-				output, _ = executeCommandC(rootCmd, "generate", "--resource-type", tc.resourceType, "--account", cloudflareTestAccountID)
+				args = append(args, "--account", cloudflareTestAccountID)
 			} else {
-				viper.Set("zone", cloudflareTestZoneID)
-				output, _ = executeCommandC(rootCmd, "generate", "--resource-type", tc.resourceType, "--zone", cloudflareTestZoneID)
+				args = append(args, "--zone", cloudflareTestZoneID)
 			}
+			output, _ := executeGenerate(t, &generator.Options{APIV0: apiV0}, args...)
 
 			expected := testDataFile("v4", tc.testdataFilename)
 			assert.Equal(t, strings.TrimRight(expected, "\n"), strings.TrimRight(output, "\n"))
@@ -446,11 +383,6 @@ func TestResourceGenerationV5(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			// Reset the environment variables used in test to ensure we don't
-			// have both present at once.
-			viper.Set("zone", "")
-			viper.Set("account", "")
-
 			var r *recorder.Recorder
 			var err error
 			if os.Getenv("OVERWRITE_VCR_CASSETTES") == "true" {
@@ -493,44 +425,46 @@ func TestResourceGenerationV5(t *testing.T) {
 				return nil
 			})
 
-			output := ""
-			api = cloudflare.NewClient(option.WithHTTPClient(
+			api := cloudflare.NewClient(option.WithHTTPClient(
 				&http.Client{
 					Transport: r,
 				},
 			))
-			apiV0, _ = cfv0.New(viper.GetString("key"), viper.GetString("email"), cfv0.HTTPClient(
+			apiV0, _ := cfv0.New(viper.GetString("key"), viper.GetString("email"), cfv0.HTTPClient(
 				&http.Client{
 					Transport: r,
 				},
 			))
+			// placeholder files for values the API doesn't return are created
+			// in the test's own directory.
+			args := []string{"generate", "--resource-type", tc.resourceType, "--stub-dir", t.TempDir()}
 			if tc.identiferType == "account" {
-				viper.Set("account", cloudflareTestAccountID)
-				if tc.cliFlags != "" {
-					output, _ = executeCommandC(rootCmd, "generate",
-						"--resource-type", tc.resourceType,
-						"--account", cloudflareTestAccountID,
-						"--resource-id", tc.cliFlags,
-					)
-				} else {
-					output, _ = executeCommandC(rootCmd, "generate", "--resource-type", tc.resourceType, "--account", cloudflareTestAccountID)
-				}
-
+				args = append(args, "--account", cloudflareTestAccountID)
 			} else {
-				viper.Set("zone", cloudflareTestZoneID)
-				if tc.cliFlags != "" {
-					output, _ = executeCommandC(rootCmd, "generate",
-						"--resource-type", tc.resourceType,
-						"--zone", cloudflareTestZoneID,
-						"--resource-id", tc.cliFlags,
-					)
-				} else {
-					output, _ = executeCommandC(rootCmd, "generate", "--resource-type", tc.resourceType, "--zone", cloudflareTestZoneID)
-				}
-
+				args = append(args, "--zone", cloudflareTestZoneID)
+			}
+			if tc.cliFlags != "" {
+				args = append(args, "--resource-id", tc.cliFlags)
 			}
+			output, _ := executeGenerate(t, &generator.Options{API: api, APIV0: apiV0}, args...)
 			expected := testDataFile("v5", tc.testdataFilename)
 			assert.Equal(t, strings.TrimRight(expected, "\n"), strings.TrimRight(output, "\n"))
 		})
 	}
 }
+
+// executeGenerate runs the command in args with the API clients of opts and
+// returns its output. The flags and context it sets on the root command are
+// restored once the test is done so that test cases don't leak into each
+// other.
+func executeGenerate(t *testing.T, opts *generator.Options, args ...string) (string, error) {
+	t.Helper()
+	for _, name := range []string{"zone", "account", "resource-type", "resource-id", "provider-schema-file", "terraform-binary-path"} {
+		restoreFlag(t, rootCmd.PersistentFlags(), name)
+	}
+	restoreFlag(t, generateCmd.Flags(), "stub-dir")
+	t.Cleanup(func() { rootCmd.SetContext(context.Background()) })
+
+	rootCmd.SetContext(withRunOptions(context.Background(), opts))
+	return executeCommandC(rootCmd, args...)
+}
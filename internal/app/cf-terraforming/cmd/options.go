@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	// transformerFlags are the `--transformer` values in the format
+	// `<resource type>=<executable>`.
+	transformerFlags []string
+
+	// transformerTimeout is the value of `--transformer-timeout`, which
+	// bounds how long a single transformer may run for.
+	transformerTimeout time.Duration
+)
+
+type runOptionsKey struct{}
+
+// withRunOptions returns a copy of ctx carrying opts.
+func withRunOptions(ctx context.Context, opts *generator.Options) context.Context {
+	return context.WithValue(ctx, runOptionsKey{}, opts)
+}
+
+// runOptionsFrom returns the generator options carried by ctx, or nil.
+func runOptionsFrom(ctx context.Context) *generator.Options {
+	if ctx == nil {
+		return nil
+	}
+	opts, _ := ctx.Value(runOptionsKey{}).(*generator.Options)
+	return opts
+}
+
+// runOptionsFor returns the generator options that sharedPreRun created for
+// cmd.
+func runOptionsFor(cmd *cobra.Command) *generator.Options {
+	opts := runOptionsFrom(cmd.Context())
+	if opts == nil {
+		log.Fatal("the command was run without initialising its options")
+	}
+	return opts
+}
+
+// newRunOptions creates the generator options from the configuration,
+// including the HTTP client unless the resources are read from a snapshot.
+func newRunOptions(cmd *cobra.Command) (*generator.Options, error) {
+	opts := &generator.Options{
+		AccountID:          viper.GetString("account"),
+		ZoneID:             viper.GetString("zone"),
+		Transforms:         viper.GetString("transforms"),
+		TransformerTimeout: transformerTimeout,
+		Logger:             log,
+	}
+
+	if opts.AccountID != "" && opts.ZoneID != "" {
+		return nil, errors.New("--account and --zone are mutually exclusive, support for both is deprecated")
+	}
+
+	var err error
+	if opts.ResourceIDs, err = parseResourceIDs(resourceIDFlags); err != nil {
+		return nil, err
+	}
+	if opts.Transformers, err = parseTransformers(transformerFlags); err != nil {
+		return nil, err
+	}
+
+	// When reading from a snapshot, no API clients are needed and the scope
+	// defaults to the one the snapshot was taken with.
+	if opts.FromSnapshotDir = viper.GetString("from-snapshot"); opts.FromSnapshotDir != "" {
+		manifest, err := readSnapshotManifest(opts.FromSnapshotDir)
+		if err != nil {
+			return nil, err
+		}
+		if opts.AccountID == "" && opts.ZoneID == "" {
+			opts.AccountID = manifest.AccountID
+			opts.ZoneID = manifest.ZoneID
+		}
+
+		log.WithFields(logrus.Fields{
+			"directory":  opts.FromSnapshotDir,
+			"zone_id":    opts.ZoneID,
+			"account_id": opts.AccountID,
+		}).Debug("using snapshot instead of the Cloudflare API")
+		return opts, nil
+	}
+
+	token, err := resolveAPIToken(viper.GetString("token"), viper.GetString("token-file"), viper.GetString("token-command"))
+	if err != nil {
+		return nil, err
+	}
+	opts.APIToken, opts.OriginCAKey = token, viper.GetString("origin-ca-key")
+
+	if token == "" {
+		opts.APIEmail = viper.GetString("email")
+		opts.APIKey = viper.GetString("key")
+
+		// an Origin CA key on its own is enough to export origin certificates.
+		if opts.OriginCAKey == "" || opts.APIEmail != "" || opts.APIKey != "" {
+			if opts.APIEmail == "" {
+				log.Error("'email' must be set.")
+			}
+
+			if opts.APIKey == "" {
+				log.Error("either -t/--token, --token-file, --token-command or -k/--key must be set.")
+			}
+		}
+
+		log.WithFields(logrus.Fields{
+			"email":      opts.APIEmail,
+			"zone_id":    opts.ZoneID,
+			"account_id": opts.AccountID,
+		}).Debug("initializing cloudflare-go")
+	} else {
+		log.WithFields(logrus.Fields{
+			"zone_id":    opts.ZoneID,
+			"account_Id": opts.AccountID,
+		}).Debug("initializing cloudflare-go with API Token")
+	}
+
+	if opts.BaseURL, err = resolveAPIBaseURL(viper.GetString("api-base-url"), viper.GetString("hostname")); err != nil {
+		return nil, err
+	}
+
+	baseTransport, err := newHTTPTransport(transportConfig{
+		ProxyURL:   viper.GetString("proxy-url"),
+		CABundle:   viper.GetString("ca-bundle"),
+		ClientCert: viper.GetString("client-cert"),
+		ClientKey:  viper.GetString("client-key"),
+		Timeout:    viper.GetDuration("timeout"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = &userAgentTransport{rt: baseTransport}
+	if recordFile = viper.GetString("record"); recordFile != "" {
+		apiRecorder, err = newAPIRecorder(recordFile, viper.GetString("record-rules"), transport)
+		if err != nil {
+			return nil, err
+		}
+		// Save what has been recorded so far should the run be aborted.
+		logrus.RegisterExitHandler(stopAPIRecorder)
+		transport = apiRecorder
+	}
+
+	opts.HTTPClient = &http.Client{
+		Transport: transport,
+		Timeout:   viper.GetDuration("request-timeout"),
+	}
+
+	if opts.CacheDir = viper.GetString("cache-dir"); opts.CacheDir != "" {
+		opts.CacheTTL, opts.Refresh = viper.GetDuration("cache-ttl"), viper.GetBool("refresh")
+	}
+
+	// Don't initialise a client in CI as this messes with VCR and the ability to
+	// mock out the HTTP interactions. The clients of the options the command
+	// is executed with are used instead.
+	if os.Getenv("CI") == "true" {
+		if base := runOptionsFrom(cmd.Root().Context()); base != nil {
+			opts.API, opts.APIV0 = base.API, base.APIV0
+		}
+	}
+	return opts, nil
+}
+
+// parseResourceIDs returns the IDs given with `--resource-id` for each
+// resource type that is fetched per parent ID. Values without a resource type
+// belong to the last one named.
+func parseResourceIDs(flags []string) (map[string][]string, error) {
+	mappings := make(map[string][]string)
+
+	var rType string
+	for _, flag := range flags {
+		if strings.Contains(flag, "=") {
+			flagParts := strings.Split(flag, "=")
+			rType = strings.TrimSpace(flagParts[0])
+			if !generator.SupportsParentIDs(rType) {
+				return nil, fmt.Errorf("unsupported resource type: %s", rType)
+			}
+			mappings[rType] = append(mappings[rType], strings.TrimSpace(flagParts[1]))
+		} else {
+			mappings[rType] = append(mappings[rType], strings.TrimSpace(flag))
+		}
+	}
+	return mappings, nil
+}
+
+// parseTransformers returns the executable for each resource type from the
+// `--transformer` flags.
+func parseTransformers(flags []string) (map[string]string, error) {
+	result := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		rType, executable, ok := strings.Cut(flag, "=")
+		rType, executable = strings.TrimSpace(rType), strings.TrimSpace(executable)
+		if !ok || rType == "" || executable == "" {
+			return nil, fmt.Errorf("invalid --transformer %q, expected <resource type>=<executable>", flag)
+		}
+		if _, ok := generator.Endpoint(rType); !ok {
+			return nil, fmt.Errorf("invalid --transformer %q: %s is not a supported resource type", flag, rType)
+		}
+		if existing, ok := result[rType]; ok {
+			return nil, fmt.Errorf("more than one --transformer for %s: %s and %s", rType, existing, executable)
+		}
+		result[rType] = executable
+	}
+	return result, nil
+}
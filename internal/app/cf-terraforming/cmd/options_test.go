@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceIDs(t *testing.T) {
+	flags := []string{"cloudflare_zone_setting=always_online", "cache_level", "cloudflare_list_item=abc"}
+
+	// repeated calls don't accumulate IDs.
+	_, _ = parseResourceIDs(flags)
+	mappings, err := parseResourceIDs(flags)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"always_online", "cache_level"}, mappings["cloudflare_zone_setting"])
+	assert.Equal(t, []string{"abc"}, mappings["cloudflare_list_item"])
+	assert.NotContains(t, mappings, "cloudflare_dns_record")
+
+	_, err = parseResourceIDs([]string{"cloudflare_dns_record=abc"})
+	assert.EqualError(t, err, "unsupported resource type: cloudflare_dns_record")
+}
+
+func TestParseTransformers(t *testing.T) {
+	got, err := parseTransformers([]string{"cloudflare_dns_record=/usr/local/bin/fix-records", "cloudflare_zone_setting = ./settings.py"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"cloudflare_dns_record":   "/usr/local/bin/fix-records",
+		"cloudflare_zone_setting": "./settings.py",
+	}, got)
+
+	_, err = parseTransformers([]string{"/usr/local/bin/fix-records"})
+	assert.ErrorContains(t, err, "expected <resource type>=<executable>")
+	_, err = parseTransformers([]string{"cloudflare_not_a_resource=fix"})
+	assert.ErrorContains(t, err, "cloudflare_not_a_resource is not a supported resource type")
+	_, err = parseTransformers([]string{"cloudflare_dns_record=a", "cloudflare_dns_record=b"})
+	assert.ErrorContains(t, err, "more than one --transformer for cloudflare_dns_record")
+}
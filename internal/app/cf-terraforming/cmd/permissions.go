@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Permission group scopes as shown when creating an API token.
+const (
+	permissionScopeAccount = "account"
+	permissionScopeZone    = "zone"
+	permissionScopeUser    = "user"
+)
+
+// originCAKeyRequirement is listed in place of the permission groups of
+// resource types that need the Origin CA key.
+const originCAKeyRequirement = "Origin CA key (--origin-ca-key)"
+
+var permissionsOutput string
+
+var permissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "List the API token permission groups needed to read resource types",
+	Long: `List the read permission groups an API token needs for cf-terraforming to
+fetch the given resource types, along with the endpoint that is called. When
+--zone or --account is provided, only the permission groups for that scope are
+listed.`,
+	Run: runPermissions,
+}
+
+// resourcePermissions are the permission groups needed to read a single
+// resource type.
+type resourcePermissions struct {
+	ResourceType     string            `json:"resource_type"`
+	Endpoint         string            `json:"endpoint"`
+	PermissionGroups []permissionGroup `json:"permission_groups"`
+	// OriginCAKey is set for resource types that need the Origin CA key
+	// instead of any permission group.
+	OriginCAKey bool `json:"origin_ca_key,omitempty"`
+}
+
+type permissionGroup struct {
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+}
+
+func init() {
+	rootCmd.AddCommand(permissionsCmd)
+	permissionsCmd.Flags().StringVarP(&permissionsOutput, "output", "o", "table", "Output format, one of: table, json")
+}
+
+func runPermissions(cmd *cobra.Command, args []string) {
+	resources := resourceTypesFlag(cmd)
+	if len(resources) == 0 {
+		log.Fatal("you must define at least one resource type to list permissions for")
+	}
+	if permissionsOutput != "table" && permissionsOutput != "json" {
+		log.Fatalf("unsupported output format %q, must be one of: table, json", permissionsOutput)
+	}
+
+	scope := ""
+	switch {
+	case viper.GetString("zone") != "" && viper.GetString("account") != "":
+		log.Fatal("--zone and --account are mutually exclusive, support for both is deprecated")
+	case viper.GetString("zone") != "":
+		scope = permissionScopeZone
+	case viper.GetString("account") != "":
+		scope = permissionScopeAccount
+	}
+
+	permissions := make([]resourcePermissions, 0)
+	for _, rType := range resources {
+		p, err := permissionsForResource(rType, scope)
+		if err != nil {
+			log.Fatal(err)
+		}
+		permissions = append(permissions, p)
+	}
+
+	var err error
+	if permissionsOutput == "json" {
+		err = writePermissionsJSON(cmd.OutOrStdout(), permissions)
+	} else {
+		err = writePermissionsTable(cmd.OutOrStdout(), permissions)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// permissionsForResource returns the permission groups needed to read rType.
+// When scope is empty, the groups for every scope are returned.
+func permissionsForResource(rType, scope string) (resourcePermissions, error) {
+	endpoint, ok := generator.Endpoint(rType)
+	if !ok {
+		return resourcePermissions{}, fmt.Errorf("%q is not a supported resource type", rType)
+	}
+	if generator.NeedsOriginCAKey(rType) {
+		return resourcePermissions{ResourceType: rType, Endpoint: endpoint, PermissionGroups: []permissionGroup{}, OriginCAKey: true}, nil
+	}
+	groups, ok := generator.PermissionGroups(rType)
+	if !ok {
+		return resourcePermissions{}, fmt.Errorf("no permission groups are known for %q", rType)
+	}
+
+	p := resourcePermissions{ResourceType: rType, Endpoint: endpoint, PermissionGroups: []permissionGroup{}}
+	for _, s := range []string{permissionScopeAccount, permissionScopeZone, permissionScopeUser} {
+		// user level permissions are needed regardless of the scope of the run.
+		if scope != "" && s != scope && s != permissionScopeUser {
+			continue
+		}
+		for _, name := range groups[s] {
+			p.PermissionGroups = append(p.PermissionGroups, permissionGroup{Scope: s, Name: name})
+		}
+	}
+
+	if scope != "" && len(p.PermissionGroups) == 0 {
+		return p, fmt.Errorf("%q cannot be read at the %s level", rType, scope)
+	}
+	return p, nil
+}
+
+// writePermissionsTable outputs one row per resource type and scope.
+func writePermissionsTable(w io.Writer, permissions []resourcePermissions) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RESOURCE TYPE\tSCOPE\tPERMISSION GROUPS\tENDPOINT")
+	for _, p := range permissions {
+		byScope := map[string][]string{}
+		scopes := []string{}
+		for _, g := range p.PermissionGroups {
+			if _, ok := byScope[g.Scope]; !ok {
+				scopes = append(scopes, g.Scope)
+			}
+			byScope[g.Scope] = append(byScope[g.Scope], g.Name)
+		}
+		for _, s := range scopes {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.ResourceType, s, strings.Join(byScope[s], ", "), p.Endpoint)
+		}
+		if p.OriginCAKey {
+			_, _ = fmt.Fprintf(tw, "%s\t-\t%s\t%s\n", p.ResourceType, originCAKeyRequirement, p.Endpoint)
+		}
+	}
+	return tw.Flush()
+}
+
+// writePermissionsJSON outputs the permissions per resource type along with
+// the deduplicated set of permission groups for all of them, which is what is
+// needed to create a single token.
+func writePermissionsJSON(w io.Writer, permissions []resourcePermissions) error {
+	seen := map[permissionGroup]bool{}
+	required := []permissionGroup{}
+	for _, p := range permissions {
+		for _, g := range p.PermissionGroups {
+			if !seen[g] {
+				seen[g] = true
+				required = append(required, g)
+			}
+		}
+	}
+	sort.Slice(required, func(i, j int) bool {
+		if required[i].Scope != required[j].Scope {
+			return required[i].Scope < required[j].Scope
+		}
+		return required[i].Name < required[j].Name
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Resources        []resourcePermissions `json:"resources"`
+		PermissionGroups []permissionGroup     `json:"permission_groups"`
+	}{permissions, required})
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsForResource(t *testing.T) {
+	p, err := permissionsForResource("cloudflare_access_rule", "")
+	assert.NoError(t, err)
+	assert.Equal(t, resourcePermissions{
+		ResourceType: "cloudflare_access_rule",
+		Endpoint:     "/{accounts_or_zones}/{account_or_zone_id}/firewall/access_rules/rules",
+		PermissionGroups: []permissionGroup{
+			{Scope: permissionScopeAccount, Name: "Account Firewall Access Rules Read"},
+			{Scope: permissionScopeZone, Name: "Firewall Services Read"},
+		},
+	}, p)
+
+	p, err = permissionsForResource("cloudflare_access_rule", permissionScopeZone)
+	assert.NoError(t, err)
+	assert.Equal(t, []permissionGroup{{Scope: permissionScopeZone, Name: "Firewall Services Read"}}, p.PermissionGroups)
+
+	_, err = permissionsForResource("cloudflare_dns_record", permissionScopeAccount)
+	assert.Error(t, err)
+
+	// the Origin CA endpoints aren't authorised by token permissions.
+	p, err = permissionsForResource("cloudflare_origin_ca_certificate", permissionScopeZone)
+	assert.NoError(t, err)
+	assert.True(t, p.OriginCAKey)
+	assert.Empty(t, p.PermissionGroups)
+
+	_, err = permissionsForResource("cloudflare_not_real", "")
+	assert.Error(t, err)
+}
+
+func TestWritePermissions(t *testing.T) {
+	permissions := []resourcePermissions{
+		{
+			ResourceType:     "cloudflare_dns_record",
+			Endpoint:         "/zones/{zone_id}/dns_records",
+			PermissionGroups: []permissionGroup{{Scope: permissionScopeZone, Name: "DNS Read"}},
+		},
+		{
+			ResourceType:     "cloudflare_zone_dnssec",
+			Endpoint:         "/zones/{zone_id}/dnssec",
+			PermissionGroups: []permissionGroup{{Scope: permissionScopeZone, Name: "DNS Read"}},
+		},
+		{
+			ResourceType:     "cloudflare_origin_ca_certificate",
+			Endpoint:         "/certificates",
+			PermissionGroups: []permissionGroup{},
+			OriginCAKey:      true,
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writePermissionsTable(&buf, permissions))
+	assert.Equal(t, "RESOURCE TYPE                     SCOPE  PERMISSION GROUPS                ENDPOINT\n"+
+		"cloudflare_dns_record             zone   DNS Read                         /zones/{zone_id}/dns_records\n"+
+		"cloudflare_zone_dnssec            zone   DNS Read                         /zones/{zone_id}/dnssec\n"+
+		"cloudflare_origin_ca_certificate  -      Origin CA key (--origin-ca-key)  /certificates\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, writePermissionsJSON(&buf, permissions))
+	assert.Contains(t, buf.String(), `"origin_ca_key": true`)
+	assert.Contains(t, buf.String(), `"permission_groups": [
+    {
+      "scope": "zone",
+      "name": "DNS Read"
+    }
+  ]
+}`)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/spf13/viper"
+)
+
+// runPreflightIfRequested performs the preflight checks when either
+// `--preflight` or `--preflight-only` is set and reports whether the command
+// should stop because only the preflight was requested.
+func runPreflightIfRequested(opts *generator.Options, out, errOut io.Writer, resources []string) bool {
+	preflightOnly := viper.GetBool("preflight-only")
+	if !preflightOnly && !viper.GetBool("preflight") {
+		return false
+	}
+	if opts.FromSnapshotDir != "" {
+		log.Fatal("--preflight cannot be used with --from-snapshot")
+	}
+
+	w := errOut
+	if preflightOnly {
+		w = out
+	}
+
+	g, err := generator.New(*opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokenStatus, err := g.VerifyToken(context.Background())
+	if err != nil {
+		log.Fatalf("API token verification failed: %s", err)
+	}
+	_, _ = fmt.Fprintf(w, "token: %s\n\n", tokenStatus)
+
+	denied := g.Preflight(context.Background(), w, resources)
+
+	if preflightOnly {
+		if denied > 0 {
+			log.Fatalf("%d of %d resource types are not accessible with the provided credentials", denied, len(resources))
+		}
+		return true
+	}
+
+	if denied > 0 {
+		log.Warnf("%d of %d resource types are not accessible with the provided credentials", denied, len(resources))
+	}
+	return false
+}
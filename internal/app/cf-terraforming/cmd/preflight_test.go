@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightOnlyFlag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/client/v4/user/tokens/verify":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":{"id":"abc","status":"active"}}`))
+		case "/client/v4/zones/" + cloudflareTestZoneID + "/dns_records":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// the API clients aren't created in CI.
+	t.Setenv("CI", "")
+	setRootFlag(t, "api-base-url", server.URL+"/client/v4")
+	setRootFlag(t, "token", "test-token")
+	setRootFlag(t, "zone", cloudflareTestZoneID)
+	setRootFlag(t, "resource-type", "")
+	restoreFlag(t, rootCmd.PersistentFlags(), "preflight-only")
+
+	// the run stops after the preflight, so no provider schema is loaded.
+	output, err := executeCommandC(rootCmd, "generate", "--resource-type", "cloudflare_dns_record", "--preflight-only")
+	require.NoError(t, err)
+	assert.Contains(t, output, "token: active\n\n")
+	assert.Contains(t, output, "cloudflare_dns_record  accessible  /zones/"+cloudflareTestZoneID+"/dns_records\n")
+}
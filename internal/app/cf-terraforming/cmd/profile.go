@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// applyProfile applies the settings of the named profile from the `profiles`
+// section of the configuration to every flag of root and its subcommands that
+// hasn't been set on the command line. Settings use the same names as the
+// flags, so a profile can hold credentials, scope, resource types and output
+// options alike. The flags aren't marked as changed and the settings are
+// merged into the configuration, so that viper still ranks them below the
+// command line and environment variables but above the rest of the
+// configuration file.
+func applyProfile(v *viper.Viper, root *cobra.Command, name string) error {
+	profile := v.Sub("profiles." + name)
+	if profile == nil {
+		available := make([]string, 0)
+		for p := range v.GetStringMap("profiles") {
+			available = append(available, p)
+		}
+		sort.Strings(available)
+		return fmt.Errorf("profile %q not found in %s, available profiles: %s", name, v.ConfigFileUsed(), strings.Join(available, ", "))
+	}
+
+	settings := profile.AllSettings()
+	if _, ok := settings["profiles"]; ok {
+		return fmt.Errorf("profile %q cannot contain nested profiles", name)
+	}
+
+	var err error
+	visitCommandFlags(root, func(f *pflag.Flag) {
+		value, ok := settings[f.Name]
+		if !ok || f.Changed || err != nil {
+			return
+		}
+		if setErr := setFlagValue(f, value); setErr != nil {
+			err = fmt.Errorf("invalid value for %q in profile %q: %w", f.Name, name, setErr)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// settings that aren't flags, such as those only read through viper, are
+	// still made available at the top level.
+	return v.MergeConfigMap(settings)
+}
+
+// visitCommandFlags calls fn for every flag of cmd and its subcommands.
+func visitCommandFlags(cmd *cobra.Command, fn func(*pflag.Flag)) {
+	cmd.PersistentFlags().VisitAll(fn)
+	cmd.Flags().VisitAll(fn)
+	for _, c := range cmd.Commands() {
+		visitCommandFlags(c, fn)
+	}
+}
+
+// setFlagValue sets f from a configuration value without marking it as
+// changed. Lists are accepted for both slice flags and comma separated string
+// flags such as `--resource-type`.
+func setFlagValue(f *pflag.Flag, value interface{}) error {
+	if list, ok := value.([]interface{}); ok {
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			values = append(values, fmt.Sprint(item))
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			return slice.Replace(values)
+		}
+		value = strings.Join(values, ",")
+	}
+
+	if _, ok := value.(map[string]interface{}); ok {
+		return fmt.Errorf("expected a value or a list, got a map")
+	}
+
+	s := fmt.Sprint(value)
+	if slice, ok := f.Value.(pflag.SliceValue); ok {
+		// Set would append to the default rather than replace it.
+		return slice.Replace(strings.Split(s, ","))
+	}
+	return f.Value.Set(s)
+}
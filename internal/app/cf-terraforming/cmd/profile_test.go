@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProfile(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	assert.NoError(t, v.ReadConfig(bytes.NewBufferString(`
+token: default-token
+profiles:
+  staging:
+    token: staging-token
+    account: staging-account
+    resource-type:
+      - cloudflare_dns_record
+      - cloudflare_page_rule
+    resource-id:
+      - cloudflare_zone_setting=always_online
+    modern-import-block: true
+    cache-ttl: 5m
+`)))
+
+	var (
+		token, account, resources, zone string
+		modern                          bool
+		ids                             []string
+	)
+	root := &cobra.Command{Use: "root"}
+	root.PersistentFlags().StringVar(&token, "token", "", "")
+	root.PersistentFlags().StringVar(&account, "account", "", "")
+	root.PersistentFlags().StringVar(&zone, "zone", "", "")
+	root.PersistentFlags().StringVar(&resources, "resource-type", "", "")
+	root.PersistentFlags().StringSliceVar(&ids, "resource-id", []string{"default"}, "")
+	sub := &cobra.Command{Use: "sub"}
+	sub.Flags().BoolVar(&modern, "modern-import-block", false, "")
+	root.AddCommand(sub)
+
+	// flags provided on the command line take precedence.
+	assert.NoError(t, root.PersistentFlags().Set("account", "flag-account"))
+
+	assert.NoError(t, applyProfile(v, root, "staging"))
+	assert.Equal(t, "staging-token", token)
+	assert.Equal(t, "flag-account", account)
+	assert.Equal(t, "", zone)
+	assert.Equal(t, "cloudflare_dns_record,cloudflare_page_rule", resources)
+	assert.Equal(t, []string{"cloudflare_zone_setting=always_online"}, ids)
+	assert.True(t, modern)
+
+	// settings without a flag are available at the top level.
+	assert.Equal(t, "5m", v.GetString("cache-ttl"))
+	assert.Equal(t, "staging-token", v.GetString("token"))
+
+	err := applyProfile(v, root, "production")
+	assert.ErrorContains(t, err, `profile "production" not found`)
+	assert.ErrorContains(t, err, "available profiles: staging")
+}
+
+func TestApplyProfilePrecedence(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	assert.NoError(t, v.ReadConfig(bytes.NewBufferString(`
+token: default-token
+zone: default-zone
+email: default-email
+profiles:
+  staging:
+    token: staging-token
+    zone: staging-zone
+    email: staging-email
+`)))
+
+	root := &cobra.Command{Use: "root"}
+	for _, name := range []string{"token", "zone", "email"} {
+		root.PersistentFlags().String(name, "", "")
+		assert.NoError(t, v.BindPFlag(name, root.PersistentFlags().Lookup(name)))
+	}
+	assert.NoError(t, v.BindEnv("token", "CLOUDFLARE_API_TOKEN"))
+	assert.NoError(t, v.BindEnv("zone", "CLOUDFLARE_ZONE_ID"))
+	t.Setenv("CLOUDFLARE_API_TOKEN", "env-token")
+	t.Setenv("CLOUDFLARE_ZONE_ID", "env-zone")
+	assert.NoError(t, root.PersistentFlags().Set("zone", "flag-zone"))
+
+	// flags > environment > profile > configuration.
+	assert.NoError(t, applyProfile(v, root, "staging"))
+	assert.Equal(t, "flag-zone", v.GetString("zone"))
+	assert.Equal(t, "env-token", v.GetString("token"))
+	assert.Equal(t, "staging-email", v.GetString("email"))
+	assert.False(t, root.PersistentFlags().Lookup("email").Changed)
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+)
+
+// bundledSchemasFS holds gzipped `terraform providers schema -json` output
+// for releases of the Cloudflare provider, named cloudflare_<version>.json.gz.
+// See schemas/README.md for how to add a release.
+//
+//go:embed schemas
+var bundledSchemasFS embed.FS
+
+// bundledSchemas is the file system bundled schemas are read from.
+var bundledSchemas fs.FS = bundledSchemasFS
+
+// cloudflareProviderPath is the suffix of the registry path of the Cloudflare
+// provider, regardless of the registry it is installed from.
+const cloudflareProviderPath = "/cloudflare/cloudflare"
+
+// readProviderSchemaFile reads the schema of the Cloudflare provider from a
+// file written by `terraform providers schema -json`. The file doesn't include
+// the version of the provider, so pinnedVersion, the `--provider-version`, is
+// returned when it is set. Otherwise only the major version is returned,
+// inferred from the resource types in the schema.
+func readProviderSchemaFile(path, pinnedVersion string) (string, *tfjson.ProviderSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read provider schema file: %w", err)
+	}
+
+	registryPath, s, err := parseProviderSchemas(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("provider schema file %s: %w", path, err)
+	}
+
+	providerVersion, err := providerMajorVersion(s)
+	if err != nil {
+		return "", nil, fmt.Errorf("provider schema file %s: %w", path, err)
+	}
+	if pinnedVersion != "" {
+		v, err := parseProviderVersion(pinnedVersion)
+		if err != nil {
+			return "", nil, err
+		}
+		if major := strconv.Itoa(v.Segments()[0]); major != providerVersion {
+			return "", nil, fmt.Errorf("provider schema file %s is for v%s of the provider, not %s", path, providerVersion, v)
+		}
+		providerVersion = v.String()
+	}
+
+	log.WithFields(logrus.Fields{
+		"version":  providerVersion,
+		"registry": registryPath,
+		"file":     path,
+	}).Info("read provider schema from file")
+	return providerVersion, s, nil
+}
+
+// parseProviderSchemas returns the registry path and schema of the Cloudflare
+// provider in the output of `terraform providers schema -json`.
+func parseProviderSchemas(data []byte) (string, *tfjson.ProviderSchema, error) {
+	var schemas tfjson.ProviderSchemas
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return "", nil, fmt.Errorf("failed to parse provider schemas: %w", err)
+	}
+
+	registryPath, s := cloudflareProviderSchema(schemas.Schemas)
+	if s == nil {
+		return "", nil, errors.New("doesn't contain the Cloudflare provider")
+	}
+	return registryPath, s, nil
+}
+
+// cloudflareProviderSchema returns the registry path and schema of the
+// Cloudflare provider in schemas, or nil if it isn't one of them.
+func cloudflareProviderSchema(schemas map[string]*tfjson.ProviderSchema) (string, *tfjson.ProviderSchema) {
+	paths := make([]string, 0, len(schemas))
+	for path := range schemas {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, cloudflareProviderPath) {
+			return path, schemas[path]
+		}
+	}
+	return "", nil
+}
+
+// providerMajorVersion returns the major version of the provider s is the
+// schema of. v5 renamed cloudflare_record to cloudflare_dns_record.
+func providerMajorVersion(s *tfjson.ProviderSchema) (string, error) {
+	switch {
+	case s.ResourceSchemas["cloudflare_dns_record"] != nil:
+		return "5", nil
+	case s.ResourceSchemas["cloudflare_record"] != nil:
+		return "4", nil
+	}
+	return "", errors.New("unable to tell the provider version from the schema")
+}
+
+// bundledSchemaName returns the name of the bundled schema of v.
+func bundledSchemaName(v *version.Version) string {
+	return "cloudflare_" + v.String() + ".json.gz"
+}
+
+// bundledSchemaVersions returns the provider versions that schemas are bundled
+// for, oldest first.
+func bundledSchemaVersions() []*version.Version {
+	entries, err := fs.ReadDir(bundledSchemas, "schemas")
+	if err != nil {
+		return nil
+	}
+
+	var versions []*version.Version
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, "cloudflare_") || !strings.HasSuffix(name, ".json.gz") {
+			continue
+		}
+		v, err := version.NewVersion(strings.TrimSuffix(strings.TrimPrefix(name, "cloudflare_"), ".json.gz"))
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Sort(version.Collection(versions))
+	return versions
+}
+
+// schemaNotBundledError is returned for provider versions without a bundled
+// schema.
+type schemaNotBundledError struct {
+	version   *version.Version
+	available []*version.Version
+}
+
+func (e *schemaNotBundledError) Error() string {
+	if len(e.available) == 0 {
+		return fmt.Sprintf("no provider schema is bundled for %s, this build doesn't bundle any", e.version)
+	}
+	available := make([]string, 0, len(e.available))
+	for _, v := range e.available {
+		available = append(available, v.String())
+	}
+	return fmt.Sprintf("no provider schema is bundled for %s, available versions: %s", e.version, strings.Join(available, ", "))
+}
+
+// parseProviderVersion parses a `--provider-version` value, such as 5.1.0.
+func parseProviderVersion(raw string) (*version.Version, error) {
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider version %q: %w", raw, err)
+	}
+	return v, nil
+}
+
+// loadBundledSchema returns the bundled schema of the provider release raw.
+func loadBundledSchema(raw string) (string, *tfjson.ProviderSchema, error) {
+	v, err := parseProviderVersion(raw)
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := bundledSchemas.Open(path.Join("schemas", bundledSchemaName(v)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, &schemaNotBundledError{version: v, available: bundledSchemaVersions()}
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decompress the bundled schema of %s: %w", v, err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decompress the bundled schema of %s: %w", v, err)
+	}
+
+	_, s, err := parseProviderSchemas(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("bundled schema of %s: %w", v, err)
+	}
+
+	log.WithField("version", v.String()).Info("using bundled provider schema")
+	return v.String(), s, nil
+}
+
+// dependencyLockFile is the file Terraform records the selected provider
+// versions of a working directory in.
+const dependencyLockFile = ".terraform.lock.hcl"
+
+// lockedProviderVersion returns the version of the Cloudflare provider in the
+// dependency lock file of the Terraform working directory dir, or "" if there
+// is none.
+func lockedProviderVersion(dir string) (string, error) {
+	path := filepath.Join(dir, dependencyLockFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	f, diags := hclparse.NewParser().ParseHCL(data, path)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	var lock struct {
+		Providers []struct {
+			Address string   `hcl:"address,label"`
+			Version string   `hcl:"version,optional"`
+			Remain  hcl.Body `hcl:",remain"`
+		} `hcl:"provider,block"`
+		Remain hcl.Body `hcl:",remain"`
+	}
+	if diags := gohcl.DecodeBody(f.Body, nil, &lock); diags.HasErrors() {
+		return "", diags
+	}
+	for _, p := range lock.Providers {
+		if strings.HasSuffix(p.Address, cloudflareProviderPath) {
+			return p.Version, nil
+		}
+	}
+	return "", nil
+}
+
+// warnOnProviderVersionMismatch warns when the Cloudflare provider locked in
+// the Terraform working directory dir isn't the release raw.
+func warnOnProviderVersionMismatch(dir, raw string) {
+	locked, err := lockedProviderVersion(dir)
+	if err != nil {
+		log.WithError(err).Debug("failed to read the dependency lock file")
+		return
+	}
+	if locked == "" {
+		return
+	}
+
+	want, err := parseProviderVersion(raw)
+	if err != nil {
+		return
+	}
+	if got, err := version.NewVersion(locked); err == nil && got.Equal(want) {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"provider_version":  want.String(),
+		"workspace_version": locked,
+		"lock_file":         filepath.Join(dir, dependencyLockFile),
+	}).Warn("the Cloudflare provider of the Terraform working directory differs from --provider-version, the generated configuration may not match it")
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// dnsRecordSchema is a minimal provider schema for cloudflare_dns_record.
+var dnsRecordSchema = &tfjson.ProviderSchema{
+	ResourceSchemas: map[string]*tfjson.Schema{
+		"cloudflare_dns_record": {Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"id":      {AttributeType: cty.String, Computed: true},
+				"zone_id": {AttributeType: cty.String, Required: true},
+				"name":    {AttributeType: cty.String, Required: true},
+				"type":    {AttributeType: cty.String, Required: true},
+				"content": {AttributeType: cty.String, Optional: true},
+			},
+		}},
+	},
+}
+
+// writeProviderSchemaFile writes schemas in the format of `terraform providers
+// schema -json` and returns the path of the file.
+func writeProviderSchemaFile(t *testing.T, schemas map[string]*tfjson.ProviderSchema) string {
+	t.Helper()
+	data, err := json.Marshal(tfjson.ProviderSchemas{FormatVersion: "1.0", Schemas: schemas})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestReadProviderSchemaFile(t *testing.T) {
+	path := writeProviderSchemaFile(t, map[string]*tfjson.ProviderSchema{
+		"registry.terraform.io/hashicorp/random":      {ResourceSchemas: map[string]*tfjson.Schema{}},
+		"registry.terraform.io/cloudflare/cloudflare": dnsRecordSchema,
+	})
+	version, s, err := readProviderSchemaFile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "5", version)
+	assert.Contains(t, s.ResourceSchemas, "cloudflare_dns_record")
+
+	v4 := &tfjson.ProviderSchema{ResourceSchemas: map[string]*tfjson.Schema{"cloudflare_record": dnsRecordSchema.ResourceSchemas["cloudflare_dns_record"]}}
+	version, _, err = readProviderSchemaFile(writeProviderSchemaFile(t, map[string]*tfjson.ProviderSchema{
+		"registry.opentofu.org/cloudflare/cloudflare": v4,
+	}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "4", version)
+
+	// the file doesn't record the exact version, --provider-version does.
+	version, _, err = readProviderSchemaFile(path, "v5.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "5.1.0", version)
+	_, _, err = readProviderSchemaFile(path, "4.52.0")
+	assert.ErrorContains(t, err, "is for v5 of the provider, not 4.52.0")
+	_, _, err = readProviderSchemaFile(path, "latest")
+	assert.ErrorContains(t, err, "invalid provider version")
+
+	_, _, err = readProviderSchemaFile(writeProviderSchemaFile(t, map[string]*tfjson.ProviderSchema{
+		"registry.terraform.io/hashicorp/random": {ResourceSchemas: map[string]*tfjson.Schema{}},
+	}), "")
+	assert.ErrorContains(t, err, "doesn't contain the Cloudflare provider")
+
+	_, _, err = readProviderSchemaFile(writeProviderSchemaFile(t, map[string]*tfjson.ProviderSchema{
+		"registry.terraform.io/cloudflare/cloudflare": {ResourceSchemas: map[string]*tfjson.Schema{}},
+	}), "")
+	assert.ErrorContains(t, err, "unable to tell the provider version")
+
+	_, _, err = readProviderSchemaFile(filepath.Join(t.TempDir(), "missing.json"), "")
+	assert.ErrorContains(t, err, "failed to read provider schema file")
+}
+
+func TestGenerateWithProviderSchemaFile(t *testing.T) {
+	t.Setenv("CI", "true")
+	t.Setenv("USE_STATIC_RESOURCE_IDS", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[{"id":"abc","name":"example.com","type":"A","content":"192.0.2.1"}]}`))
+	}))
+	defer server.Close()
+
+	path := writeProviderSchemaFile(t, map[string]*tfjson.ProviderSchema{
+		"registry.terraform.io/cloudflare/cloudflare": dnsRecordSchema,
+	})
+	api := cloudflare.NewClient(option.WithBaseURL(server.URL), option.WithHTTPClient(server.Client()))
+
+	// Terraform must not be looked up.
+	output, err := executeGenerate(t, &generator.Options{API: api}, "generate",
+		"--resource-type", "cloudflare_dns_record",
+		"--zone", cloudflareTestZoneID,
+		"--provider-schema-file", path,
+		"--terraform-binary-path", filepath.Join(t.TempDir(), "missing"),
+	)
+	require.NoError(t, err)
+	assert.Contains(t, output, `resource "cloudflare_dns_record" "terraform_managed_resource_abc_0" {`)
+}
+
+// gzipProviderSchemas returns schemas in the format of `terraform providers
+// schema -json`, gzipped.
+func gzipProviderSchemas(t *testing.T, schemas map[string]*tfjson.ProviderSchema) []byte {
+	t.Helper()
+	data, err := json.Marshal(tfjson.ProviderSchemas{FormatVersion: "1.0", Schemas: schemas})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err = zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestLoadBundledSchema(t *testing.T) {
+	defer func(fsys fs.FS) { bundledSchemas = fsys }(bundledSchemas)
+
+	bundledSchemas = fstest.MapFS{"schemas/README.md": {Data: []byte("# Bundled provider schemas")}}
+	_, _, err := loadBundledSchema("5.1.0")
+	assert.EqualError(t, err, "no provider schema is bundled for 5.1.0, this build doesn't bundle any")
+
+	bundledSchemas = fstest.MapFS{
+		"schemas/README.md":                 {Data: []byte("# Bundled provider schemas")},
+		"schemas/cloudflare_5.10.0.json.gz": {Data: gzipProviderSchemas(t, map[string]*tfjson.ProviderSchema{"registry.terraform.io/cloudflare/cloudflare": dnsRecordSchema})},
+		"schemas/cloudflare_5.2.0.json.gz":  {Data: []byte("not gzip")},
+	}
+
+	v, s, err := loadBundledSchema("v5.10.0")
+	require.NoError(t, err)
+	assert.Equal(t, "5.10.0", v)
+	assert.Contains(t, s.ResourceSchemas, "cloudflare_dns_record")
+
+	_, _, err = loadBundledSchema("5.1.0")
+	assert.EqualError(t, err, "no provider schema is bundled for 5.1.0, available versions: 5.2.0, 5.10.0")
+	_, _, err = loadBundledSchema("5.2.0")
+	assert.ErrorContains(t, err, "failed to decompress the bundled schema of 5.2.0")
+	_, _, err = loadBundledSchema("latest")
+	assert.ErrorContains(t, err, `invalid provider version "latest"`)
+}
+
+func TestEmbeddedSchemas(t *testing.T) {
+	versions := bundledSchemaVersions()
+	if len(versions) == 0 {
+		t.Skip("no provider schemas are bundled, add them with scripts/build-provider-schema-snapshot")
+	}
+
+	// every bundled schema must be used as is rather than falling back to a
+	// bootstrapped workspace.
+	for _, v := range versions {
+		got, s, err := loadBundledSchema(v.String())
+		require.NoError(t, err, v.String())
+		assert.Equal(t, v.String(), got)
+		major, err := providerMajorVersion(s)
+		require.NoError(t, err, v.String())
+		assert.Equal(t, strconv.Itoa(v.Segments()[0]), major, v.String())
+	}
+}
+
+func TestLockedProviderVersion(t *testing.T) {
+	dir := t.TempDir()
+	v, err := lockedProviderVersion(dir)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, dependencyLockFile), []byte(`# This file is maintained automatically by "terraform init".
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+  hashes  = ["h1:abc="]
+}
+
+provider "registry.terraform.io/cloudflare/cloudflare" {
+  version     = "5.1.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:def=",
+  ]
+}
+`), 0600))
+	v, err = lockedProviderVersion(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "5.1.0", v)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	warnOnProviderVersionMismatch(dir, "5.1.0")
+	assert.Empty(t, buf.String())
+	warnOnProviderVersionMismatch(dir, "5.2.0")
+	assert.Contains(t, buf.String(), "differs from --provider-version")
+	assert.Contains(t, buf.String(), "workspace_version=5.1.0")
+}
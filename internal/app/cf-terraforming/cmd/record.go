@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/dnaeon/go-vcr/recorder"
+	"github.com/spf13/viper"
+)
+
+// redactedValue replaces header values and JSON values that are scrubbed from
+// a recording.
+const redactedValue = "REDACTED"
+
+var (
+	recordFile, recordRulesFile string
+
+	// apiRecorder captures the API interactions of the current run when
+	// `--record` is provided.
+	apiRecorder *recorder.Recorder
+
+	// defaultRedactionRules are always applied to recordings. Rules from
+	// `--record-rules` are added to these.
+	defaultRedactionRules = redactionRules{
+		Headers: []string{
+			"Authorization",
+			"Cookie",
+			"Set-Cookie",
+			"X-Auth-Email",
+			"X-Auth-Key",
+			"X-Auth-User-Service-Key",
+		},
+		Keys: []string{
+			`[a-z_]*secret[a-z_]*`,
+			`[a-z_]*token[a-z_]*`,
+			`[a-z_]*password[a-z_]*`,
+			`[a-z_]*private_key[a-z_]*`,
+			`psk`,
+		},
+		Patterns: []redactionPattern{
+			{Name: "email", Regex: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`, Replacement: "user@example.com"},
+			{Name: "ipv4", Regex: `\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}(?:/[0-9]{1,2})?\b`, Replacement: "192.0.2.1"},
+			{Name: "ipv6", Regex: `\b(?:[0-9a-fA-F]{1,4}:){2,7}(?::|[0-9a-fA-F]{1,4})(?:/[0-9]{1,3})?`, Replacement: "2001:db8::1"},
+		},
+	}
+)
+
+// redactionRules describe what is scrubbed from a recording before it is
+// written to disk.
+type redactionRules struct {
+	// Headers are removed from both requests and responses.
+	Headers []string `mapstructure:"headers"`
+
+	// Keys are regular expressions matched against JSON object keys. String
+	// values of matching keys are replaced with `REDACTED`.
+	Keys []string `mapstructure:"keys"`
+
+	// Patterns are regular expressions replaced anywhere in URLs and bodies.
+	Patterns []redactionPattern `mapstructure:"patterns"`
+}
+
+type redactionPattern struct {
+	Name        string `mapstructure:"name"`
+	Regex       string `mapstructure:"regex"`
+	Replacement string `mapstructure:"replacement"`
+}
+
+// loadRedactionRules returns the default rules merged with any rules found in
+// path. Supported formats are the same as the main configuration file.
+func loadRedactionRules(path string) (redactionRules, error) {
+	rules := redactionRules{
+		Headers:  append([]string{}, defaultRedactionRules.Headers...),
+		Keys:     append([]string{}, defaultRedactionRules.Keys...),
+		Patterns: append([]redactionPattern{}, defaultRedactionRules.Patterns...),
+	}
+	if path == "" {
+		return rules, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return rules, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+
+	var custom redactionRules
+	if err := v.Unmarshal(&custom); err != nil {
+		return rules, fmt.Errorf("failed to parse redaction rules: %w", err)
+	}
+
+	rules.Headers = append(rules.Headers, custom.Headers...)
+	rules.Keys = append(rules.Keys, custom.Keys...)
+	rules.Patterns = append(rules.Patterns, custom.Patterns...)
+	return rules, nil
+}
+
+// newRedactionFilter compiles rules into a filter that scrubs an interaction
+// in place.
+func newRedactionFilter(rules redactionRules) (cassette.Filter, error) {
+	var keyRe *regexp.Regexp
+	if len(rules.Keys) > 0 {
+		re, err := regexp.Compile(`("(?i:` + strings.Join(rules.Keys, "|") + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key: %w", err)
+		}
+		keyRe = re
+	}
+
+	type compiledPattern struct {
+		re          *regexp.Regexp
+		replacement string
+	}
+	patterns := make([]compiledPattern, 0, len(rules.Patterns))
+	for _, p := range rules.Patterns {
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p.Name, err)
+		}
+		replacement := p.Replacement
+		if replacement == "" {
+			replacement = redactedValue
+		}
+		patterns = append(patterns, compiledPattern{re: re, replacement: replacement})
+	}
+
+	scrub := func(s string) string {
+		if keyRe != nil {
+			s = keyRe.ReplaceAllString(s, `${1}"`+redactedValue+`"`)
+		}
+		for _, p := range patterns {
+			s = p.re.ReplaceAllString(s, p.replacement)
+		}
+		return s
+	}
+
+	return func(i *cassette.Interaction) error {
+		for _, h := range rules.Headers {
+			i.Request.Headers.Del(h)
+			i.Response.Headers.Del(h)
+		}
+
+		i.Request.URL = scrub(i.Request.URL)
+		i.Request.Body = scrub(i.Request.Body)
+		i.Response.Body = scrub(i.Response.Body)
+		return nil
+	}, nil
+}
+
+// newAPIRecorder wraps rt in a recorder that writes every interaction to path
+// once stopped, scrubbed according to the default and user provided rules.
+func newAPIRecorder(path, rulesPath string, rt http.RoundTripper) (*recorder.Recorder, error) {
+	rules, err := loadRedactionRules(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newRedactionFilter(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// go-vcr always appends the extension to the cassette name.
+	r, err := recorder.NewAsMode(strings.TrimSuffix(path, ".yaml"), recorder.ModeRecording, rt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	r.AddSaveFilter(filter)
+	return r, nil
+}
+
+// stopAPIRecorder writes the recording to disk if one is in progress.
+func stopAPIRecorder() {
+	if apiRecorder == nil {
+		return
+	}
+	if err := apiRecorder.Stop(); err != nil {
+		log.Errorf("failed to save recording: %s", err)
+		return
+	}
+	log.Infof("recorded API interactions to %s", recordFile)
+	apiRecorder = nil
+}
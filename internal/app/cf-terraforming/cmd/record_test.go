@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRecorderRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "__cfruid=abc123")
+		_, _ = io.WriteString(w, `{"result":{"email":"jane@corp.example","tunnel_secret":"c2VjcmV0","client_secret":"hunter2","origin":"198.51.100.7","ipv6":"2606:4700:4700::1111","name":"internal-app"},"success":true}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.yaml")
+	err := os.WriteFile(rulesPath, []byte("patterns:\n  - name: internal names\n    regex: internal-[a-z]+\n    replacement: example-app\n"), 0644)
+	assert.NoError(t, err)
+
+	cassettePath := filepath.Join(dir, "bug-report.yaml")
+	r, err := newAPIRecorder(cassettePath, rulesPath, http.DefaultTransport)
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/client/v4/accounts/abc/members?email=jane@corp.example", nil)
+	req.Header.Set("Authorization", "Bearer supersecret")
+	req.Header.Set("X-Auth-Key", "supersecret")
+	resp, err := (&http.Client{Transport: r}).Do(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	// the response handed back to the caller must not be modified.
+	assert.Contains(t, string(body), "jane@corp.example")
+
+	assert.NoError(t, r.Stop())
+	recorded, err := os.ReadFile(cassettePath)
+	assert.NoError(t, err)
+
+	for _, leaked := range []string{"supersecret", "jane@corp.example", "c2VjcmV0", "hunter2", "198.51.100.7", "2606:4700:4700::1111", "__cfruid", "internal-app"} {
+		assert.NotContains(t, string(recorded), leaked)
+	}
+	assert.Contains(t, string(recorded), `"tunnel_secret":"REDACTED"`)
+	assert.Contains(t, string(recorded), "user@example.com")
+	assert.Contains(t, string(recorded), "example-app")
+}
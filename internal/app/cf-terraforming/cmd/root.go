@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"strings"
+	"time"
 
-	cfv0 "github.com/cloudflare/cloudflare-go"
-	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -14,15 +14,11 @@ import (
 var (
 	log = logrus.New()
 
-	cfgFile, zoneID, hostname, apiEmail                                 string
-	apiKey, apiToken, accountID                                         string
+	cfgFile                                                             string
 	terraformInstallPath, terraformBinaryPath, providerRegistryHostname string
 
 	verbose, useModernImportBlock bool
 
-	apiV0 *cfv0.API
-	api   *cloudflare.Client
-
 	// rootCmd represents the base command when called without any subcommands.
 	rootCmd = &cobra.Command{
 		Use:   "cf-terraforming",
@@ -30,35 +26,10 @@ var (
 		Long: `cf-terraforming is an application that allows Cloudflare users
 to be able to adopt Terraform by giving them a feasible way to get
 all of their existing Cloudflare configuration into Terraform.`,
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			stopAPIRecorder()
+		},
 	}
-
-	// Resources for which path params are supported.
-	settingsMap = map[string][]string{
-		"cloudflare_zone_setting":                                    make([]string, 0),
-		"cloudflare_hostname_tls_setting":                            make([]string, 0),
-		"cloudflare_waiting_room_event":                              make([]string, 0),
-		"cloudflare_r2_managed_domain":                               make([]string, 0),
-		"cloudflare_r2_custom_domain":                                make([]string, 0),
-		"cloudflare_pages_domain":                                    make([]string, 0),
-		"cloudflare_list_item":                                       make([]string, 0),
-		"cloudflare_zero_trust_dlp_predefined_profile":               make([]string, 0),
-		"cloudflare_web_analytics_rule":                              make([]string, 0),
-		"cloudflare_waiting_room_rules":                              make([]string, 0),
-		"cloudflare_zero_trust_tunnel_cloudflared_config":            make([]string, 0),
-		"cloudflare_workers_script_subdomain":                        make([]string, 0),
-		"cloudflare_workers_deployment":                              make([]string, 0),
-		"cloudflare_workers_cron_trigger":                            make([]string, 0),
-		"cloudflare_authenticated_origin_pulls":                      make([]string, 0),
-		"cloudflare_queue_consumer":                                  make([]string, 0),
-		"cloudflare_api_shield_operation_schema_validation_settings": make([]string, 0),
-		"cloudflare_observatory_scheduled_test":                      make([]string, 0),
-		"cloudflare_zero_trust_dlp_custom_profile":                   make([]string, 0),
-	}
-)
-
-const (
-	terraformImportCmdPrefix    = "terraform import"
-	terraformResourceNamePrefix = "terraform_managed_resource"
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -80,11 +51,18 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", home+"/.cf-terraforming.yaml", "Path to config file")
+	rootCmd.PersistentFlags().String("profile", "", "Name of the profile in the config file to use. Flags take precedence over profile settings")
+	if err = viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("profile", "CF_TERRAFORMING_PROFILE"); err != nil {
+		log.Fatal(err)
+	}
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Specify verbose output (same as setting log level to debug)")
-	rootCmd.PersistentFlags().StringVar(&resourceType, "resource-type", "", "Comma delimitered string of which resource(s) you wish to generate")
+	rootCmd.PersistentFlags().String("resource-type", "", "Comma delimitered string of which resource(s) you wish to generate")
 	rootCmd.PersistentFlags().BoolVarP(&useModernImportBlock, "modern-import-block", "", false, "Whether to generate HCL import blocks for generated resources instead of terraform import compatible CLI commands. This is only compatible with Terraform 1.5+")
 
-	rootCmd.PersistentFlags().StringVarP(&zoneID, "zone", "z", "", "Target the provided zone ID for the command")
+	rootCmd.PersistentFlags().StringP("zone", "z", "", "Target the provided zone ID for the command")
 	if err = viper.BindPFlag("zone", rootCmd.PersistentFlags().Lookup("zone")); err != nil {
 		log.Fatal(err)
 	}
@@ -92,7 +70,7 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&accountID, "account", "a", "", "Target the provided account ID for the command")
+	rootCmd.PersistentFlags().StringP("account", "a", "", "Target the provided account ID for the command")
 	if err = viper.BindPFlag("account", rootCmd.PersistentFlags().Lookup("account")); err != nil {
 		log.Fatal(err)
 	}
@@ -100,7 +78,7 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&apiEmail, "email", "e", "", "API Email address associated with your account")
+	rootCmd.PersistentFlags().StringP("email", "e", "", "API Email address associated with your account")
 	if err = viper.BindPFlag("email", rootCmd.PersistentFlags().Lookup("email")); err != nil {
 		log.Fatal(err)
 	}
@@ -108,7 +86,7 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&apiKey, "key", "k", "", "API Key generated on the 'My Profile' page. See: https://dash.cloudflare.com/profile")
+	rootCmd.PersistentFlags().StringP("key", "k", "", "API Key generated on the 'My Profile' page. See: https://dash.cloudflare.com/profile")
 	if err = viper.BindPFlag("key", rootCmd.PersistentFlags().Lookup("key")); err != nil {
 		log.Fatal(err)
 	}
@@ -116,7 +94,7 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&apiToken, "token", "t", "", "API Token")
+	rootCmd.PersistentFlags().StringP("token", "t", "", "API Token")
 	if err = viper.BindPFlag("token", rootCmd.PersistentFlags().Lookup("token")); err != nil {
 		log.Fatal(err)
 	}
@@ -124,7 +102,31 @@ func init() {
 		log.Fatal(err)
 	}
 
-	rootCmd.PersistentFlags().StringVarP(&hostname, "hostname", "", "", "Hostname to use to query the API")
+	rootCmd.PersistentFlags().String("token-file", "", "Path to a file containing the API Token")
+	if err = viper.BindPFlag("token-file", rootCmd.PersistentFlags().Lookup("token-file")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("token-file", "CLOUDFLARE_API_TOKEN_FILE"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("token-command", "", "Command that prints the API Token to stdout, run using the system shell")
+	if err = viper.BindPFlag("token-command", rootCmd.PersistentFlags().Lookup("token-command")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("token-command", "CLOUDFLARE_API_TOKEN_COMMAND"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("origin-ca-key", "", "Origin CA key used only for the Origin CA certificate endpoints. See: https://dash.cloudflare.com/profile/api-tokens")
+	if err = viper.BindPFlag("origin-ca-key", rootCmd.PersistentFlags().Lookup("origin-ca-key")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("origin-ca-key", "CLOUDFLARE_API_USER_SERVICE_KEY", "CLOUDFLARE_ORIGIN_CA_KEY"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("hostname", "", "Hostname to use to query the API. Deprecated: use --api-base-url instead.")
 	if err = viper.BindPFlag("hostname", rootCmd.PersistentFlags().Lookup("hostname")); err != nil {
 		log.Fatal(err)
 	}
@@ -132,6 +134,14 @@ func init() {
 		log.Fatal(err)
 	}
 
+	rootCmd.PersistentFlags().String("api-base-url", "", "Base URL of the Cloudflare API including scheme, host, port and path, e.g. http://localhost:8080/client/v4. Applies to all API clients")
+	if err = viper.BindPFlag("api-base-url", rootCmd.PersistentFlags().Lookup("api-base-url")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("api-base-url", "CLOUDFLARE_API_BASE_URL", "CLOUDFLARE_BASE_URL"); err != nil {
+		log.Fatal(err)
+	}
+
 	rootCmd.PersistentFlags().StringVar(&terraformInstallPath, "terraform-install-path", ".", "Path to an initialized Terraform working directory")
 	if err = viper.BindPFlag("terraform-install-path", rootCmd.PersistentFlags().Lookup("terraform-install-path")); err != nil {
 		log.Fatal(err)
@@ -148,6 +158,46 @@ func init() {
 		log.Fatal(err)
 	}
 
+	rootCmd.PersistentFlags().String("terraform-version", defaultTerraformConstraint, "Version constraint of the Terraform release to install when --terraform-binary-path isn't set, such as \"= 1.9.0\"")
+	if err = viper.BindPFlag("terraform-version", rootCmd.PersistentFlags().Lookup("terraform-version")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-version", "CLOUDFLARE_TERRAFORM_VERSION"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("terraform-zip", "", "Path to a Terraform release archive, such as terraform_1.9.0_linux_amd64.zip, to install Terraform from. The terraform_<version>_SHA256SUMS file and its signature must be next to it")
+	if err = viper.BindPFlag("terraform-zip", rootCmd.PersistentFlags().Lookup("terraform-zip")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-zip", "CLOUDFLARE_TERRAFORM_ZIP"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("terraform-mirror", "", "Directory or URL laid out like releases.hashicorp.com to install Terraform from instead of releases.hashicorp.com")
+	if err = viper.BindPFlag("terraform-mirror", rootCmd.PersistentFlags().Lookup("terraform-mirror")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-mirror", "CLOUDFLARE_TERRAFORM_MIRROR"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("terraform-public-key", "", "Path to an armored PGP public key to verify the checksums of installed Terraform releases with instead of HashiCorp's")
+	if err = viper.BindPFlag("terraform-public-key", rootCmd.PersistentFlags().Lookup("terraform-public-key")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-public-key", "CLOUDFLARE_TERRAFORM_PUBLIC_KEY"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("terraform-cache-dir", "", "Directory installed Terraform releases are cached in by version. Defaults to a directory in the user cache directory")
+	if err = viper.BindPFlag("terraform-cache-dir", rootCmd.PersistentFlags().Lookup("terraform-cache-dir")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-cache-dir", "CLOUDFLARE_TERRAFORM_CACHE_DIR"); err != nil {
+		log.Fatal(err)
+	}
+
 	rootCmd.PersistentFlags().StringVarP(&providerRegistryHostname, "provider-registry-hostname", "", "", "Hostname to use for provider registry lookups. Deprecated: this is no longer needed to be configured for custom registries.")
 	if err = viper.BindPFlag("provider-registry-hostname", rootCmd.PersistentFlags().Lookup("provider-registry-hostname")); err != nil {
 		log.Fatal(err)
@@ -155,6 +205,142 @@ func init() {
 	if err = viper.BindEnv("provider-registry-hostname", "CLOUDFLARE_PROVIDER_REGISTRY_HOSTNAME"); err != nil {
 		log.Fatal(err)
 	}
+	rootCmd.PersistentFlags().String("terraform-plugin-dir", "", "Directory, such as a filesystem mirror, to install providers from when bootstrapping a Terraform workspace instead of the registry")
+	if err = viper.BindPFlag("terraform-plugin-dir", rootCmd.PersistentFlags().Lookup("terraform-plugin-dir")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-plugin-dir", "CLOUDFLARE_TERRAFORM_PLUGIN_DIR"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("terraform-plugin-cache-dir", "", "Plugin cache directory to reuse providers from when bootstrapping a Terraform workspace. TF_PLUGIN_CACHE_DIR is used when it is not set")
+	if err = viper.BindPFlag("terraform-plugin-cache-dir", rootCmd.PersistentFlags().Lookup("terraform-plugin-cache-dir")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("terraform-plugin-cache-dir", "CLOUDFLARE_TERRAFORM_PLUGIN_CACHE_DIR"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("provider-schema-file", "", "Path to the output of \"terraform providers schema -json\" to read the provider schema from instead of a Terraform working directory. Terraform isn't needed when it is set")
+	if err = viper.BindPFlag("provider-schema-file", rootCmd.PersistentFlags().Lookup("provider-schema-file")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("provider-schema-file", "CLOUDFLARE_PROVIDER_SCHEMA_FILE"); err != nil {
+		log.Fatal(err)
+	}
+	rootCmd.PersistentFlags().String("provider-version", "", "Release of the Cloudflare provider, such as 5.1.0, to use the provider schema bundled with cf-terraforming for instead of a Terraform working directory, or that the --provider-schema-file is for. Terraform isn't needed when it is set")
+	if err = viper.BindPFlag("provider-version", rootCmd.PersistentFlags().Lookup("provider-version")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("provider-version", "CLOUDFLARE_PROVIDER_VERSION"); err != nil {
+		log.Fatal(err)
+	}
+	rootCmd.PersistentFlags().String("from-snapshot", "", "Path to a directory created by \"cf-terraforming snapshot\" to read API responses from instead of the Cloudflare API")
+	if err = viper.BindPFlag("from-snapshot", rootCmd.PersistentFlags().Lookup("from-snapshot")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("from-snapshot", "CLOUDFLARE_FROM_SNAPSHOT"); err != nil {
+		log.Fatal(err)
+	}
+	rootCmd.PersistentFlags().String("proxy-url", "", "Proxy to send API requests through. Defaults to the HTTPS_PROXY environment variable")
+	if err = viper.BindPFlag("proxy-url", rootCmd.PersistentFlags().Lookup("proxy-url")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("proxy-url", "CLOUDFLARE_PROXY_URL"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("ca-bundle", "", "Path to a PEM file of additional certificate authorities to trust, such as the CA of an intercepting proxy")
+	if err = viper.BindPFlag("ca-bundle", rootCmd.PersistentFlags().Lookup("ca-bundle")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("ca-bundle", "CLOUDFLARE_CA_BUNDLE"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("client-cert", "", "Path to a PEM encoded client certificate to present for mTLS")
+	if err = viper.BindPFlag("client-cert", rootCmd.PersistentFlags().Lookup("client-cert")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("client-cert", "CLOUDFLARE_CLIENT_CERT"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("client-key", "", "Path to the PEM encoded private key for --client-cert")
+	if err = viper.BindPFlag("client-key", rootCmd.PersistentFlags().Lookup("client-key")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("client-key", "CLOUDFLARE_CLIENT_KEY"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Duration("request-timeout", 0, "Maximum duration of a single API request, e.g. 30s. Zero means no limit")
+	if err = viper.BindPFlag("request-timeout", rootCmd.PersistentFlags().Lookup("request-timeout")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("request-timeout", "CLOUDFLARE_REQUEST_TIMEOUT"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Duration("timeout", 0, "Maximum total duration of all API requests in the run, counted from the first request, e.g. 10m. Zero means no limit")
+	if err = viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("timeout", "CLOUDFLARE_TIMEOUT"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("preflight", false, "Verify the API token and probe the list endpoint of every resource type before generating")
+	if err = viper.BindPFlag("preflight", rootCmd.PersistentFlags().Lookup("preflight")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("preflight-only", false, "Run the checks of --preflight and exit, with a non-zero status if any resource type is denied")
+	if err = viper.BindPFlag("preflight-only", rootCmd.PersistentFlags().Lookup("preflight-only")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory to cache encrypted API responses in between runs. Caching is disabled when empty")
+	if err = viper.BindPFlag("cache-dir", rootCmd.PersistentFlags().Lookup("cache-dir")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("cache-dir", "CLOUDFLARE_CACHE_DIR"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Duration("cache-ttl", 15*time.Minute, "How long cached API responses are used for")
+	if err = viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("cache-ttl", "CLOUDFLARE_CACHE_TTL"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().Bool("refresh", false, "Ignore cached API responses and fetch them again, updating the cache")
+	if err = viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringVar(&recordFile, "record", "", "Write a go-vcr cassette of every API interaction to this file, with credentials and personal data scrubbed, for attaching to bug reports")
+	if err = viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringVar(&recordRulesFile, "record-rules", "", "Path to a file of additional redaction rules (headers, keys and patterns) to apply to --record")
+	if err = viper.BindPFlag("record-rules", rootCmd.PersistentFlags().Lookup("record-rules")); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().String("transforms", "", "Path to a YAML file of transform rules for API responses, replacing the built-in rules of the resource types it lists")
+	if err = viper.BindPFlag("transforms", rootCmd.PersistentFlags().Lookup("transforms")); err != nil {
+		log.Fatal(err)
+	}
+	if err = viper.BindEnv("transforms", "CF_TERRAFORMING_TRANSFORMS"); err != nil {
+		log.Fatal(err)
+	}
+
+	rootCmd.PersistentFlags().StringArrayVar(&transformerFlags, "transformer", []string{}, "Executable that rewrites the resources of a type, in the format `<resource type>=<executable>`. It reads a JSON array of objects on stdin and prints the modified array on stdout. Can be repeated")
+	rootCmd.PersistentFlags().DurationVar(&transformerTimeout, "transformer-timeout", generator.DefaultTransformerTimeout, "How long a --transformer may run for each resource type")
 	rootCmd.PersistentFlags().StringSliceVar(&resourceIDFlags, "resource-id", []string{}, "Resource type and IDs mapping in the format of `key` to comma separated values. Example: `cloudflare_zone_setting=always_online,cache_level,...`")
 }
 
@@ -182,6 +368,13 @@ func initConfig() {
 		log.Debug("using config file:", viper.ConfigFileUsed())
 	}
 
+	if profile := viper.GetString("profile"); profile != "" {
+		if err := applyProfile(viper.GetViper(), rootCmd, profile); err != nil {
+			log.Fatal(err)
+		}
+		log.Debug("using profile:", profile)
+	}
+
 	var cfgLogLevel = logrus.InfoLevel
 
 	if verbose {
@@ -191,20 +384,11 @@ func initConfig() {
 	log.SetLevel(cfgLogLevel)
 }
 
-func getResourceMappings() map[string][]string {
-	var rType string
-	for _, flag := range resourceIDFlags {
-		if strings.Contains(flag, "=") {
-			flagParts := strings.Split(flag, "=")
-			rType = strings.TrimSpace(flagParts[0])
-			_, ok := settingsMap[rType]
-			if !ok {
-				log.Fatalf("unsupported resource type: %s", rType)
-			}
-			settingsMap[rType] = append(settingsMap[rType], strings.TrimSpace(flagParts[1]))
-		} else {
-			settingsMap[rType] = append(settingsMap[rType], strings.TrimSpace(flag))
-		}
+// resourceTypesFlag returns the resource types given with `--resource-type`.
+func resourceTypesFlag(cmd *cobra.Command) []string {
+	value, _ := cmd.Flags().GetString("resource-type")
+	if value == "" {
+		return nil
 	}
-	return settingsMap
+	return strings.Split(value, ",")
 }
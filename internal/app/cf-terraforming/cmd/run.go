@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var jobsFile string
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Generate Terraform configuration for every job in a job file",
+	Long: `Generate Terraform configuration for several accounts and zones in a single
+run. Each job in the job file names a scope, the resource types to generate,
+optional resource IDs and filters, and the directory the configuration is
+written to, with one file per resource type and imports.tf with the import
+blocks for the generated resources. Filters keep only the resources whose top
+level attributes have the given values:
+
+  jobs:
+    - name: production-dns
+      zone: 0da42c8d2132a9ddaf714f9e7c920711
+      resource_types: [cloudflare_dns_record, cloudflare_zone_setting]
+      resource_ids:
+        cloudflare_zone_setting: [always_online, cache_level]
+      filters:
+        cloudflare_dns_record:
+          type: CNAME
+      output_dir: production/dns
+    - name: workers
+      account: f037e56e89293a057740de681ac9abbe
+      resource_types: [cloudflare_workers_script]
+      output_dir: production/workers
+
+The provider schema is only loaded once. A failing job doesn't stop the others
+from running; a summary of all jobs is printed at the end and the exit code is
+non-zero if any of them failed.`,
+	PreRun: sharedPreRun,
+	Run:    runJobs,
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().StringVar(&jobsFile, "jobs", "", "Path to the YAML or JSON file describing the jobs to run")
+}
+
+// job is a single export described in a job file.
+type job struct {
+	Name          string              `mapstructure:"name"`
+	Account       string              `mapstructure:"account"`
+	Zone          string              `mapstructure:"zone"`
+	ResourceTypes []string            `mapstructure:"resource_types"`
+	ResourceIDs   map[string][]string `mapstructure:"resource_ids"`
+	// Filters are the values of top level attributes, keyed by resource type
+	// and attribute, that resources must have to be generated.
+	Filters   map[string]map[string]string `mapstructure:"filters"`
+	OutputDir string                       `mapstructure:"output_dir"`
+}
+
+// scope returns a description of the account or zone the job targets.
+func (j job) scope() string {
+	if j.Account != "" {
+		return "account " + j.Account
+	}
+	return "zone " + j.Zone
+}
+
+// jobResult is the outcome of running a single job.
+type jobResult struct {
+	job       job
+	resources int
+	files     int
+	err       error
+}
+
+// loadJobs reads and validates the jobs in the job file at path.
+func loadJobs(path string) ([]job, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read job file %s: %w", path, err)
+	}
+
+	var jobs []job
+	if err := v.UnmarshalKey("jobs", &jobs); err != nil {
+		return nil, fmt.Errorf("failed to parse job file %s: %w", path, err)
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("job file %s doesn't contain any jobs", path)
+	}
+
+	names := make(map[string]bool, len(jobs))
+	for i := range jobs {
+		j := &jobs[i]
+		if j.Name == "" {
+			j.Name = fmt.Sprintf("job-%d", i+1)
+		}
+		if names[j.Name] {
+			return nil, fmt.Errorf("job %q is defined more than once", j.Name)
+		}
+		names[j.Name] = true
+
+		if (j.Account == "") == (j.Zone == "") {
+			return nil, fmt.Errorf("job %q must set exactly one of account or zone", j.Name)
+		}
+		if len(j.ResourceTypes) == 0 {
+			return nil, fmt.Errorf("job %q must list at least one resource type", j.Name)
+		}
+		if j.OutputDir == "" {
+			return nil, fmt.Errorf("job %q must set output_dir", j.Name)
+		}
+		for rType := range j.ResourceIDs {
+			if !generator.SupportsParentIDs(rType) {
+				return nil, fmt.Errorf("job %q: resource IDs are not supported for %s", j.Name, rType)
+			}
+		}
+		for rType := range j.Filters {
+			if !slices.Contains(j.ResourceTypes, rType) {
+				return nil, fmt.Errorf("job %q: filters are given for %s which isn't one of its resource types", j.Name, rType)
+			}
+		}
+	}
+	return jobs, nil
+}
+
+func runJobs(cmd *cobra.Command, args []string) {
+	if jobsFile == "" {
+		log.Fatal("you must provide a job file with --jobs")
+	}
+	jobs, err := loadJobs(jobsFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := runOptionsFor(cmd)
+	var schema *tfjson.ProviderSchema
+	opts.ProviderVersion, schema = loadProviderSchema()
+
+	results := make([]jobResult, 0, len(jobs))
+	for _, j := range jobs {
+		results = append(results, runJob(*opts, j, schema))
+	}
+
+	if err := writeJobSummary(cmd.OutOrStdout(), results); err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		log.Fatalf("%d of %d jobs failed", failed, len(results))
+	}
+}
+
+// runJob generates every resource type of j into its own file in the output
+// directory of the job, along with imports.tf holding the import blocks for
+// all of them. The scope, resource IDs and filters of the job replace those of
+// opts for the job.
+func runJob(opts generator.Options, j job, s *tfjson.ProviderSchema) jobResult {
+	result := jobResult{job: j}
+	logger := log.WithField("job", j.Name)
+	logger.WithField("scope", j.scope()).Info("running job")
+
+	opts.AccountID, opts.ZoneID = j.Account, j.Zone
+	// files referenced by the configuration are relative to the module.
+	opts.StubDir = j.OutputDir
+	opts.ResourceIDs, opts.Filters = j.ResourceIDs, j.Filters
+
+	if err := os.MkdirAll(j.OutputDir, 0755); err != nil {
+		result.err = err
+		return result
+	}
+
+	g, err := generator.New(opts)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer g.LogStubFiles()
+
+	var failures []error
+	var imports []generator.ImportBlock
+	for _, rType := range j.ResourceTypes {
+		var out, notices bytes.Buffer
+		typeImports, err := g.Generate(context.Background(), &out, &notices, s, []string{rType})
+		imports = append(imports, typeImports...)
+		if notices.Len() > 0 {
+			logger.WithField("resource", rType).Info(notices.String())
+		}
+		if err != nil {
+			failures = append(failures, err)
+		}
+		if out.Len() == 0 {
+			continue
+		}
+
+		count, err := countResourceBlocks(out.Bytes())
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", rType, err))
+			continue
+		}
+		path := filepath.Join(j.OutputDir, rType+".tf")
+		if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		result.resources += count
+		result.files++
+	}
+
+	if len(imports) > 0 {
+		importFile := hclwrite.NewEmptyFile()
+		generator.WriteImportBlocks(importFile.Body(), imports)
+		if err := os.WriteFile(filepath.Join(j.OutputDir, "imports.tf"), importFile.Bytes(), 0644); err != nil {
+			failures = append(failures, err)
+		} else {
+			result.files++
+		}
+	}
+
+	result.err = errors.Join(failures...)
+	if result.err != nil {
+		logger.WithError(result.err).Error("job failed")
+	}
+	return result
+}
+
+// countResourceBlocks returns the number of resource blocks in the generated
+// configuration.
+func countResourceBlocks(src []byte) (int, error) {
+	f, diags := hclwrite.ParseConfig(src, "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return 0, diags
+	}
+	count := 0
+	for _, block := range f.Body().Blocks() {
+		if block.Type() == "resource" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// writeJobSummary writes a table with the outcome of every job to w.
+func writeJobSummary(w io.Writer, results []jobResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tSCOPE\tOUTPUT\tFILES\tRESOURCES\tSTATUS")
+	for _, r := range results {
+		status := "ok"
+		if r.err != nil {
+			status = "failed: " + strings.ReplaceAll(r.err.Error(), "\n", "; ")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\n", r.job.Name, r.job.scope(), r.job.OutputDir, r.files, r.resources, status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+jobs:
+  - name: dns
+    zone: 0da42c8d2132a9ddaf714f9e7c920711
+    resource_types: [cloudflare_dns_record, cloudflare_zone_setting]
+    resource_ids:
+      cloudflare_zone_setting: [always_online, cache_level]
+    filters:
+      cloudflare_dns_record:
+        type: CNAME
+    output_dir: out/dns
+  - account: f037e56e89293a057740de681ac9abbe
+    resource_types: [cloudflare_workers_script]
+    output_dir: out/workers
+`), 0600))
+
+	jobs, err := loadJobs(path)
+	require.NoError(t, err)
+	assert.Equal(t, []job{
+		{
+			Name:          "dns",
+			Zone:          "0da42c8d2132a9ddaf714f9e7c920711",
+			ResourceTypes: []string{"cloudflare_dns_record", "cloudflare_zone_setting"},
+			ResourceIDs:   map[string][]string{"cloudflare_zone_setting": {"always_online", "cache_level"}},
+			Filters:       map[string]map[string]string{"cloudflare_dns_record": {"type": "CNAME"}},
+			OutputDir:     "out/dns",
+		},
+		{
+			Name:          "job-2",
+			Account:       "f037e56e89293a057740de681ac9abbe",
+			ResourceTypes: []string{"cloudflare_workers_script"},
+			OutputDir:     "out/workers",
+		},
+	}, jobs)
+}
+
+func TestLoadJobsValidation(t *testing.T) {
+	tests := map[string]struct {
+		jobs string
+		err  string
+	}{
+		"no jobs":         {`{"jobs": []}`, "doesn't contain any jobs"},
+		"both scopes":     {`{"jobs": [{"name": "a", "zone": "z", "account": "a", "resource_types": ["cloudflare_dns_record"], "output_dir": "out"}]}`, `job "a" must set exactly one of account or zone`},
+		"no scope":        {`{"jobs": [{"name": "a", "resource_types": ["cloudflare_dns_record"], "output_dir": "out"}]}`, `job "a" must set exactly one of account or zone`},
+		"no types":        {`{"jobs": [{"name": "a", "zone": "z", "output_dir": "out"}]}`, `job "a" must list at least one resource type`},
+		"no output":       {`{"jobs": [{"name": "a", "zone": "z", "resource_types": ["cloudflare_dns_record"]}]}`, `job "a" must set output_dir`},
+		"duplicate names": {`{"jobs": [{"name": "a", "zone": "z", "resource_types": ["cloudflare_dns_record"], "output_dir": "out"}, {"name": "a", "zone": "z", "resource_types": ["cloudflare_dns_record"], "output_dir": "out"}]}`, `job "a" is defined more than once`},
+		"unsupported ids": {`{"jobs": [{"name": "a", "zone": "z", "resource_types": ["cloudflare_dns_record"], "resource_ids": {"cloudflare_dns_record": ["x"]}, "output_dir": "out"}]}`, "resource IDs are not supported for cloudflare_dns_record"},
+		"unknown filters": {`{"jobs": [{"name": "a", "zone": "z", "resource_types": ["cloudflare_dns_record"], "filters": {"cloudflare_zone": {"name": "x"}}, "output_dir": "out"}]}`, "filters are given for cloudflare_zone which isn't one of its resource types"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jobs.json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.jobs), 0600))
+			_, err := loadJobs(path)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestRunJobFilters(t *testing.T) {
+	t.Setenv("USE_STATIC_RESOURCE_IDS", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[
+			{"id":"abc","name":"example.com","type":"A","content":"192.0.2.1"},
+			{"id":"def","name":"www.example.com","type":"CNAME","content":"example.com"}
+		]}`))
+	}))
+	defer server.Close()
+
+	j := job{
+		Name:          "dns",
+		Zone:          cloudflareTestZoneID,
+		ResourceTypes: []string{"cloudflare_dns_record"},
+		Filters:       map[string]map[string]string{"cloudflare_dns_record": {"type": "CNAME"}},
+		OutputDir:     t.TempDir(),
+	}
+	opts := generator.Options{
+		APIToken:        "test-token",
+		ProviderVersion: "5.0.0",
+		HTTPClient:      server.Client(),
+		BaseURL:         server.URL,
+	}
+	result := runJob(opts, j, dnsRecordSchema)
+	require.NoError(t, result.err)
+	assert.Equal(t, 1, result.resources)
+
+	out, err := os.ReadFile(filepath.Join(j.OutputDir, "cloudflare_dns_record.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"www.example.com"`)
+	assert.NotContains(t, string(out), `"192.0.2.1"`)
+
+	imports, err := os.ReadFile(filepath.Join(j.OutputDir, "imports.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `import {
+  to = cloudflare_dns_record.terraform_managed_resource_def_0
+  id = "`+cloudflareTestZoneID+`/def"
+}
+
+`, string(imports))
+	assert.Equal(t, 2, result.files)
+}
+
+func TestWriteJobSummary(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJobSummary(&out, []jobResult{
+		{job: job{Name: "dns", Zone: "z1", OutputDir: "out/dns"}, files: 2, resources: 7},
+		{job: job{Name: "workers", Account: "a1", OutputDir: "out/workers"}, err: errors.New("cloudflare_workers_script: forbidden")},
+	}))
+
+	assert.Equal(t, `JOB      SCOPE       OUTPUT       FILES  RESOURCES  STATUS
+dns      zone z1     out/dns      2      7          ok
+workers  account a1  out/workers  0      0          failed: cloudflare_workers_script: forbidden
+`, out.String())
+}
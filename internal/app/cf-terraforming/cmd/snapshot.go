@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/spf13/cobra"
+)
+
+// snapshotManifestFile is the name of the file at the root of a snapshot
+// directory that records the scope the snapshot was taken with.
+const snapshotManifestFile = "manifest.json"
+
+var (
+	// snapshotDir is the directory API responses are written to while a
+	// snapshot is being taken.
+	snapshotDir string
+
+	snapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Store the raw API responses for resources in a directory for offline generation",
+		Long: `Fetch resources from the Cloudflare API and store the raw response of every
+endpoint called in a directory, keyed by resource type and endpoint. The
+directory can then be passed to "generate" or "import" using --from-snapshot to
+build configuration without any network access.`,
+		Run:    runSnapshot(),
+		PreRun: sharedPreRun,
+	}
+)
+
+// snapshotManifest describes the scope and contents of a snapshot directory.
+type snapshotManifest struct {
+	Version       string    `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+	AccountID     string    `json:"account_id,omitempty"`
+	ZoneID        string    `json:"zone_id,omitempty"`
+	ResourceTypes []string  `json:"resource_types"`
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().StringVar(&snapshotDir, "output-dir", "", "Directory to write the snapshot to")
+}
+
+func runSnapshot() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		resources := resourceTypesFlag(cmd)
+		if len(resources) == 0 {
+			log.Fatal("you must define a resource type to snapshot")
+		}
+		if snapshotDir == "" {
+			log.Fatal("you must define an --output-dir to write the snapshot to")
+		}
+		opts := runOptionsFor(cmd)
+		if opts.FromSnapshotDir != "" {
+			log.Fatal("--from-snapshot cannot be used when taking a snapshot")
+		}
+		opts.SnapshotDir = snapshotDir
+
+		if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+			log.Fatalf("failed to create snapshot directory: %s", err)
+		}
+
+		g, err := generator.New(*opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		captured, err := g.Snapshot(context.Background(), resources)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		manifest := snapshotManifest{
+			Version:       versionString,
+			CreatedAt:     time.Now().UTC(),
+			AccountID:     opts.AccountID,
+			ZoneID:        opts.ZoneID,
+			ResourceTypes: captured,
+		}
+		m, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(snapshotDir, snapshotManifestFile), m, 0644); err != nil {
+			log.Fatalf("failed to write snapshot manifest: %s", err)
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "wrote snapshot of %d resource type(s) to %s\n", len(captured), snapshotDir)
+	}
+}
+
+// readSnapshotManifest loads the manifest from the root of a snapshot directory.
+func readSnapshotManifest(dir string) (*snapshotManifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, snapshotManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	var m snapshotManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot manifest: %w", err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	stdlog "log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hc-install/product"
+	"github.com/hashicorp/hc-install/releases"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// defaultTerraformConstraint is the version constraint of Terraform that is
+// installed when `--terraform-version` isn't set.
+const defaultTerraformConstraint = "~> 1.0"
+
+// hashicorpPublicKey is the key HashiCorp signs the checksums of its releases
+// with. See https://www.hashicorp.com/security.
+//
+//go:embed hashicorp.asc
+var hashicorpPublicKey string
+
+// terraformZipPattern matches the name of a Terraform release archive, such as
+// terraform_1.9.0_linux_amd64.zip.
+var terraformZipPattern = regexp.MustCompile(`^terraform_([^_]+)_([a-z0-9]+)_([a-z0-9]+)\.zip$`)
+
+// findOrInstallTerraform returns the path of the Terraform binary to run. In
+// order of preference it is `--terraform-binary-path`, a binary in
+// `--terraform-install-path` matching `--terraform-version`, the release in
+// `--terraform-zip`, a release cached in `--terraform-cache-dir` or one
+// installed from `--terraform-mirror` or releases.hashicorp.com. Installed
+// releases are verified against their signed checksums and cached by version.
+func findOrInstallTerraform(ctx context.Context) (string, error) {
+	// Check if the user has provided an explicit path to the binary. This is the highest priority.
+	if execPath := viper.GetString("terraform-binary-path"); execPath != "" {
+		log.WithFields(logrus.Fields{
+			"terraform-binary-path": execPath,
+		}).Info("Using Terraform binary from explicit path")
+		// Quick check to ensure the file actually exists
+		if _, err := os.Stat(execPath); err != nil {
+			return "", fmt.Errorf("binary specified in 'terraform-binary-path' not found at %s: %w", execPath, err)
+		}
+		return execPath, nil
+	}
+	log.Info("terraform-binary-path flag not set")
+
+	rawConstraint := viper.GetString("terraform-version")
+	if rawConstraint == "" {
+		rawConstraint = defaultTerraformConstraint
+	}
+	constraints, err := version.NewConstraint(rawConstraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid Terraform version constraint %q: %w", rawConstraint, err)
+	}
+
+	// Earlier releases installed Terraform in the working directory.
+	if installPath := viper.GetString("terraform-install-path"); installPath != "" {
+		execPath := filepath.Join(installPath, product.Terraform.BinaryName())
+		if fileExists(execPath) {
+			v, err := terraformBinaryVersion(ctx, execPath)
+			switch {
+			case err != nil:
+				log.WithError(err).Warnf("ignoring the Terraform binary at %s", execPath)
+			case constraints.Check(v):
+				log.WithFields(logrus.Fields{
+					"path":    execPath,
+					"version": v,
+				}).Info("Using Terraform binary from the install path")
+				return execPath, nil
+			default:
+				log.WithFields(logrus.Fields{
+					"path":       execPath,
+					"version":    v,
+					"constraint": rawConstraint,
+				}).Info("ignoring the Terraform binary in the install path, it doesn't match --terraform-version")
+			}
+		}
+	}
+
+	cacheDir, err := terraformCacheDir()
+	if err != nil {
+		return "", err
+	}
+	armoredKey, keyring, err := terraformPublicKey()
+	if err != nil {
+		return "", err
+	}
+
+	if zipPath := viper.GetString("terraform-zip"); zipPath != "" {
+		return installTerraformZip(zipPath, constraints, cacheDir, keyring)
+	}
+
+	if execPath, v := cachedTerraform(cacheDir, constraints); execPath != "" {
+		log.WithFields(logrus.Fields{
+			"path":    execPath,
+			"version": v,
+		}).Info("Using cached Terraform binary")
+		return execPath, nil
+	}
+
+	mirror := viper.GetString("terraform-mirror")
+	if mirror != "" && !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+		zipPath, err := mirroredTerraformZip(mirror, constraints)
+		if err != nil {
+			return "", err
+		}
+		return installTerraformZip(zipPath, constraints, cacheDir, keyring)
+	}
+	return downloadTerraform(ctx, constraints, cacheDir, mirror, armoredKey)
+}
+
+// terraformCacheDir returns `--terraform-cache-dir`, or a directory in the
+// user's cache directory when it isn't set.
+func terraformCacheDir() (string, error) {
+	cacheDir := viper.GetString("terraform-cache-dir")
+	if cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user cache directory: %w", err)
+		}
+		cacheDir = filepath.Join(userCacheDir, viper.GetString("app-name"), "terraform")
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create Terraform cache directory at %s: %w", cacheDir, err)
+	}
+	return cacheDir, nil
+}
+
+// terraformPublicKey returns the key in `--terraform-public-key`, or
+// HashiCorp's when it isn't set, both armored and parsed.
+func terraformPublicKey() (string, openpgp.EntityList, error) {
+	armored := hashicorpPublicKey
+	if path := viper.GetString("terraform-public-key"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read Terraform public key: %w", err)
+		}
+		armored = string(data)
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse Terraform public key: %w", err)
+	}
+	return armored, keyring, nil
+}
+
+// cachedTerraform returns the path and version of the latest Terraform binary
+// in cacheDir that matches constraints, or an empty path if there is none.
+func cachedTerraform(cacheDir string, constraints version.Constraints) (string, *version.Version) {
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		return "", nil
+	}
+
+	var latest *version.Version
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.NewVersion(entry.Name())
+		if err != nil || !constraints.Check(v) {
+			continue
+		}
+		if !fileExists(cachedTerraformPath(cacheDir, v)) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+	return cachedTerraformPath(cacheDir, latest), latest
+}
+
+// cachedTerraformPath returns the path of the binary of release v in cacheDir.
+func cachedTerraformPath(cacheDir string, v *version.Version) string {
+	return filepath.Join(cacheDir, v.String(), product.Terraform.BinaryName())
+}
+
+// mirroredTerraformZip returns the archive of the latest release matching
+// constraints for this platform in mirror, a directory laid out like
+// releases.hashicorp.com.
+func mirroredTerraformZip(mirror string, constraints version.Constraints) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(mirror, product.Terraform.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read Terraform mirror: %w", err)
+	}
+
+	var latest *version.Version
+	var latestZip string
+	for _, entry := range entries {
+		v, err := version.NewVersion(entry.Name())
+		if err != nil || v.Prerelease() != "" || !constraints.Check(v) {
+			continue
+		}
+		zipPath := filepath.Join(mirror, product.Terraform.Name, entry.Name(),
+			fmt.Sprintf("terraform_%s_%s_%s.zip", entry.Name(), runtime.GOOS, runtime.GOARCH))
+		if !fileExists(zipPath) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest, latestZip = v, zipPath
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("no Terraform release matching %q for %s_%s in mirror %s", constraints, runtime.GOOS, runtime.GOARCH, mirror)
+	}
+	return latestZip, nil
+}
+
+// installTerraformZip verifies the Terraform release archive at zipPath and
+// unpacks it into cacheDir, unless that release is already cached.
+func installTerraformZip(zipPath string, constraints version.Constraints, cacheDir string, keyring openpgp.EntityList) (string, error) {
+	m := terraformZipPattern.FindStringSubmatch(filepath.Base(zipPath))
+	if m == nil {
+		return "", fmt.Errorf("%s isn't a Terraform release archive, expected a name such as terraform_1.9.0_%s_%s.zip", zipPath, runtime.GOOS, runtime.GOARCH)
+	}
+	v, err := version.NewVersion(m[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid Terraform version in %s: %w", zipPath, err)
+	}
+	if !constraints.Check(v) {
+		return "", fmt.Errorf("%s contains Terraform %s, which doesn't match --terraform-version %q", zipPath, v, constraints)
+	}
+	if m[2] != runtime.GOOS || m[3] != runtime.GOARCH {
+		return "", fmt.Errorf("%s is built for %s_%s, not %s_%s", zipPath, m[2], m[3], runtime.GOOS, runtime.GOARCH)
+	}
+
+	if execPath := cachedTerraformPath(cacheDir, v); fileExists(execPath) {
+		log.WithFields(logrus.Fields{
+			"path":    execPath,
+			"version": v,
+		}).Info("Using cached Terraform binary")
+		return execPath, nil
+	}
+
+	if err := verifyTerraformZip(zipPath, v, keyring); err != nil {
+		return "", err
+	}
+
+	stage, err := os.MkdirTemp(cacheDir, ".install-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create install directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(stage) }()
+
+	if err := unpackTerraform(zipPath, stage); err != nil {
+		return "", err
+	}
+	return cacheTerraform(stage, cacheDir, v)
+}
+
+// verifyTerraformZip checks the archive at zipPath against the SHA256SUMS file
+// of release v next to it, and the signature of that file against keyring.
+func verifyTerraformZip(zipPath string, v *version.Version, keyring openpgp.EntityList) error {
+	sumsPath := filepath.Join(filepath.Dir(zipPath), fmt.Sprintf("terraform_%s_SHA256SUMS", v.Original()))
+	sums, err := os.ReadFile(sumsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the checksums of %s: %w", zipPath, err)
+	}
+
+	sigPath := checksumSignaturePath(sumsPath, keyring)
+	sig, err := os.Open(sigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the signature of %s: %w", sumsPath, err)
+	}
+	defer sig.Close()
+	signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(sums), sig, nil)
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %w", sumsPath, err)
+	}
+	log.WithFields(logrus.Fields{
+		"checksums": sumsPath,
+		"signature": sigPath,
+		"key_id":    signer.PrimaryKey.KeyIdString(),
+	}).Info("verified the signature of the Terraform checksums")
+
+	expected, err := lookupChecksum(sums, filepath.Base(zipPath))
+	if err != nil {
+		return fmt.Errorf("%s: %w", sumsPath, err)
+	}
+	f, err := os.Open(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", zipPath, err)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("checksum of %s doesn't match %s (expected: %s, got: %s)", zipPath, sumsPath, expected, actual)
+	}
+	log.WithFields(logrus.Fields{
+		"archive": zipPath,
+		"sha256":  expected,
+	}).Info("verified the checksum of the Terraform archive")
+	return nil
+}
+
+// checksumSignaturePath returns the signature of the SHA256SUMS file at
+// sumsPath made by a key in keyring, falling back to the unqualified one.
+func checksumSignaturePath(sumsPath string, keyring openpgp.EntityList) string {
+	for _, entity := range keyring {
+		path := fmt.Sprintf("%s.%s.sig", sumsPath, entity.PrimaryKey.KeyIdShortString())
+		if fileExists(path) {
+			return path
+		}
+	}
+	return sumsPath + ".sig"
+}
+
+// lookupChecksum returns the hex encoded SHA-256 checksum of name in sums, in
+// the format of sha256sum.
+func lookupChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum for %s", name)
+}
+
+// unpackTerraform extracts the Terraform binary from the archive at zipPath
+// into dir, keeping the permissions it has in the archive.
+func unpackTerraform(zipPath, dir string) error {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", zipPath, err)
+	}
+	defer r.Close()
+
+	name := product.Terraform.BinaryName()
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		mode := f.Mode().Perm()
+		if mode&0100 == 0 {
+			// Archives created on Windows don't record permissions.
+			mode = 0755
+		}
+		src, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("failed to unpack %s: %w", zipPath, err)
+		}
+		defer src.Close()
+		dstPath := filepath.Join(dir, name)
+		dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(dst, src); err != nil {
+			_ = dst.Close()
+			return fmt.Errorf("failed to unpack %s: %w", zipPath, err)
+		}
+		if err := dst.Close(); err != nil {
+			return err
+		}
+		// The umask applies to OpenFile.
+		return os.Chmod(dstPath, mode)
+	}
+	return fmt.Errorf("%s doesn't contain %s", zipPath, name)
+}
+
+// downloadTerraform installs the latest Terraform release matching constraints
+// from baseURL, or releases.hashicorp.com when it is empty, into cacheDir.
+func downloadTerraform(ctx context.Context, constraints version.Constraints, cacheDir, baseURL, armoredKey string) (string, error) {
+	stage, err := os.MkdirTemp(cacheDir, ".install-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create install directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(stage) }()
+
+	installer := &releases.LatestVersion{
+		Product:          product.Terraform,
+		Constraints:      constraints,
+		InstallDir:       stage,
+		ArmoredPublicKey: armoredKey,
+		ApiBaseURL:       baseURL,
+	}
+	// hc-install logs the checksum and signature verification of the release.
+	w := log.WriterLevel(logrus.InfoLevel)
+	defer w.Close()
+	installer.SetLogger(stdlog.New(w, "hc-install: ", 0))
+
+	log.WithFields(logrus.Fields{
+		"constraint": constraints.String(),
+		"mirror":     baseURL,
+	}).Info("downloading Terraform")
+	execPath, err := installer.Install(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error installing Terraform: %w", err)
+	}
+
+	v, err := terraformBinaryVersion(ctx, execPath)
+	if err != nil {
+		return "", err
+	}
+	return cacheTerraform(stage, cacheDir, v)
+}
+
+// cacheTerraform moves the directory stage containing release v of Terraform
+// into cacheDir and returns the path of the binary.
+func cacheTerraform(stage, cacheDir string, v *version.Version) (string, error) {
+	execPath := cachedTerraformPath(cacheDir, v)
+	if err := os.Rename(stage, filepath.Dir(execPath)); err != nil {
+		// Another run may have cached the same release in the meantime.
+		if fileExists(execPath) {
+			return execPath, nil
+		}
+		return "", fmt.Errorf("failed to cache Terraform %s: %w", v, err)
+	}
+	log.WithFields(logrus.Fields{
+		"path":    execPath,
+		"version": v,
+	}).Info("Terraform installed successfully")
+	return execPath, nil
+}
+
+// terraformBinaryVersion runs the Terraform binary at execPath to find out its
+// version.
+func terraformBinaryVersion(ctx context.Context, execPath string) (*version.Version, error) {
+	tf, err := tfexec.NewTerraform(filepath.Dir(execPath), execPath)
+	if err != nil {
+		return nil, err
+	}
+	v, _, err := tf.Version(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the version of %s: %w", execPath, err)
+	}
+	if v == nil {
+		return nil, errors.New("terraform didn't report its version")
+	}
+	return v, nil
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReleaseKey returns a key to sign Terraform releases with in tests and the
+// path of its armored public key.
+func newReleaseKey(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Release signing", "", "releases@example.com", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	path := filepath.Join(t.TempDir(), "release.asc")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	return entity, path
+}
+
+// writeTerraformRelease writes release ver of Terraform for this platform to
+// dir like releases.hashicorp.com does, with its checksums signed by key, and
+// returns the path of the archive.
+func writeTerraformRelease(t *testing.T, dir, ver string, key *openpgp.Entity) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	header := &zip.FileHeader{Name: "terraform", Method: zip.Deflate}
+	header.SetMode(0750)
+	w, err := zw.CreateHeader(header)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(w, "#!/bin/sh\necho '{\"terraform_version\":%q}'\n", ver)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	name := fmt.Sprintf("terraform_%s_%s_%s.zip", ver, runtime.GOOS, runtime.GOARCH)
+	zipPath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(zipPath, archive.Bytes(), 0644))
+
+	sums := fmt.Sprintf("%x  %s\n", sha256.Sum256(archive.Bytes()), name)
+	sumsPath := filepath.Join(dir, fmt.Sprintf("terraform_%s_SHA256SUMS", ver))
+	require.NoError(t, os.WriteFile(sumsPath, []byte(sums), 0644))
+
+	var sig bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&sig, key, bytes.NewReader([]byte(sums)), nil))
+	require.NoError(t, os.WriteFile(sumsPath+".sig", sig.Bytes(), 0644))
+	return zipPath
+}
+
+func TestFindOrInstallTerraformFromZip(t *testing.T) {
+	key, keyPath := newReleaseKey(t)
+	cacheDir := t.TempDir()
+	setRootFlag(t, "terraform-install-path", t.TempDir())
+	setRootFlag(t, "terraform-cache-dir", cacheDir)
+	setRootFlag(t, "terraform-public-key", keyPath)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	zipPath := writeTerraformRelease(t, t.TempDir(), "1.9.0", key)
+	setRootFlag(t, "terraform-zip", zipPath)
+	execPath, err := findOrInstallTerraform(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, "1.9.0", "terraform"), execPath)
+	assert.Contains(t, buf.String(), "verified the signature of the Terraform checksums")
+	assert.Contains(t, buf.String(), "verified the checksum of the Terraform archive")
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(execPath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0750), info.Mode().Perm(), "the permissions in the archive must be kept")
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		buf.Reset()
+		setRootFlag(t, "terraform-zip", "")
+		setRootFlag(t, "terraform-version", "~> 1.9.0")
+		cached, err := findOrInstallTerraform(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, execPath, cached)
+		assert.Contains(t, buf.String(), "Using cached Terraform binary")
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		setRootFlag(t, "terraform-version", "~> 1.8.0")
+		_, err := findOrInstallTerraform(context.Background())
+		assert.ErrorContains(t, err, `which doesn't match --terraform-version "~> 1.8.0"`)
+	})
+
+	t.Run("tampered archive", func(t *testing.T) {
+		zipPath := writeTerraformRelease(t, t.TempDir(), "1.9.1", key)
+		f, err := os.OpenFile(zipPath, os.O_APPEND|os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = f.WriteString("tampered")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		setRootFlag(t, "terraform-zip", zipPath)
+		_, err = findOrInstallTerraform(context.Background())
+		assert.ErrorContains(t, err, "doesn't match")
+		assert.NoDirExists(t, filepath.Join(cacheDir, "1.9.1"))
+	})
+
+	t.Run("untrusted signature", func(t *testing.T) {
+		other, _ := newReleaseKey(t)
+		setRootFlag(t, "terraform-zip", writeTerraformRelease(t, t.TempDir(), "1.9.2", other))
+		_, err := findOrInstallTerraform(context.Background())
+		assert.ErrorContains(t, err, "invalid signature")
+		assert.NoDirExists(t, filepath.Join(cacheDir, "1.9.2"))
+	})
+}
+
+func TestFindOrInstallTerraformFromMirror(t *testing.T) {
+	key, keyPath := newReleaseKey(t)
+	mirror := t.TempDir()
+	for _, ver := range []string{"1.8.4", "1.8.5", "1.9.0"} {
+		writeTerraformRelease(t, filepath.Join(mirror, "terraform", ver), ver, key)
+	}
+	cacheDir := t.TempDir()
+	setRootFlag(t, "terraform-install-path", t.TempDir())
+	setRootFlag(t, "terraform-cache-dir", cacheDir)
+	setRootFlag(t, "terraform-public-key", keyPath)
+	setRootFlag(t, "terraform-mirror", mirror)
+	setRootFlag(t, "terraform-version", "~> 1.8.0")
+
+	execPath, err := findOrInstallTerraform(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, "1.8.5", "terraform"), execPath)
+
+	setRootFlag(t, "terraform-version", "~> 1.7.0")
+	_, err = findOrInstallTerraform(context.Background())
+	assert.ErrorContains(t, err, "no Terraform release matching")
+}
+
+func TestFindOrInstallTerraformInInstallPath(t *testing.T) {
+	installPath := t.TempDir()
+	script, err := os.ReadFile(writeFakeTerraform(t, t.TempDir()))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(installPath, "terraform"), script, 0700))
+	setRootFlag(t, "terraform-install-path", installPath)
+	setRootFlag(t, "terraform-cache-dir", t.TempDir())
+
+	setRootFlag(t, "terraform-version", "~> 1.9")
+	execPath, err := findOrInstallTerraform(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(installPath, "terraform"), execPath)
+
+	// The binary reports 1.9.0, so it is skipped and the mirror is used.
+	setRootFlag(t, "terraform-version", "~> 1.8.0")
+	setRootFlag(t, "terraform-mirror", t.TempDir())
+	_, err = findOrInstallTerraform(context.Background())
+	assert.ErrorContains(t, err, "failed to read Terraform mirror")
+}
+
+func TestTerraformPublicKey(t *testing.T) {
+	_, keyring, err := terraformPublicKey()
+	require.NoError(t, err)
+	require.Len(t, keyring, 1)
+	assert.Equal(t, "72D7468F", keyring[0].PrimaryKey.KeyIdShortString())
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// transportConfig holds the user provided options for the transport of the
+// HTTP client shared by both SDK clients. The per request timeout is set on the
+// client itself.
+type transportConfig struct {
+	// ProxyURL overrides the proxy from the HTTP(S)_PROXY environment variables.
+	ProxyURL string
+	// CABundle is a PEM file of certificates trusted in addition to the system
+	// pool, such as the CA of an intercepting proxy.
+	CABundle string
+	// ClientCert and ClientKey are a PEM encoded key pair presented for mTLS.
+	ClientCert, ClientKey string
+	// Timeout limits the total time spent on API requests for the whole run,
+	// counted from the first request so that installing Terraform and loading
+	// the provider schema don't count towards it.
+	Timeout time.Duration
+}
+
+// deadlineTransport is an http.RoundTripper that fails every request made
+// once timeout has passed since the first one, so that the API requests of a
+// run can be bounded as a whole.
+type deadlineTransport struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+
+	start    sync.Once
+	deadline time.Time
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.start.Do(func() { t.deadline = time.Now().Add(t.timeout) })
+	ctx, cancel := context.WithDeadline(req.Context(), t.deadline)
+	resp, err := t.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("overall timeout exceeded: %w", err)
+		}
+		return nil, err
+	}
+	// the context must outlive the response body.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the request context once the body has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// newHTTPTransport builds the base transport from cfg. It starts from a clone
+// of http.DefaultTransport so that connection pooling and the proxy
+// environment variables behave as they would otherwise.
+func newHTTPTransport(cfg transportConfig) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", cfg.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if cfg.CABundle != "" || cfg.ClientCert != "" || cfg.ClientKey != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+		if cfg.CABundle != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			pem, err := os.ReadFile(cfg.CABundle)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CABundle)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		if cfg.ClientCert != "" || cfg.ClientKey != "" {
+			if cfg.ClientCert == "" || cfg.ClientKey == "" {
+				return nil, errors.New("--client-cert and --client-key must be provided together")
+			}
+			cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	var rt http.RoundTripper = transport
+	if cfg.Timeout > 0 {
+		rt = &deadlineTransport{rt: rt, timeout: cfg.Timeout}
+	}
+	return rt, nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPTransport(t *testing.T) {
+	t.Run("trusts the provided CA bundle", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		_, err := (&http.Client{Transport: mustTransport(t, transportConfig{})}).Get(server.URL)
+		assert.Error(t, err, "the test server certificate should not be trusted by default")
+
+		caBundle := writePEM(t, "CERTIFICATE", server.Certificate().Raw)
+		resp, err := (&http.Client{Transport: mustTransport(t, transportConfig{CABundle: caBundle})}).Get(server.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	})
+
+	t.Run("presents the client certificate", func(t *testing.T) {
+		var presented int
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented = len(r.TLS.PeerCertificates)
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+		server.StartTLS()
+		defer server.Close()
+
+		// reuse the server's own key pair as the client certificate.
+		cert := server.TLS.Certificates[0]
+		key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+		assert.NoError(t, err)
+
+		cfg := transportConfig{
+			CABundle:   writePEM(t, "CERTIFICATE", server.Certificate().Raw),
+			ClientCert: writePEM(t, "CERTIFICATE", cert.Certificate[0]),
+			ClientKey:  writePEM(t, "PRIVATE KEY", key),
+		}
+		resp, err := (&http.Client{Transport: mustTransport(t, cfg)}).Get(server.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, 1, presented)
+	})
+
+	t.Run("client certificate requires a key", func(t *testing.T) {
+		_, err := newHTTPTransport(transportConfig{ClientCert: "cert.pem"})
+		assert.EqualError(t, err, "--client-cert and --client-key must be provided together")
+	})
+
+	t.Run("sends requests through the proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer proxy.Close()
+
+		resp, err := (&http.Client{Transport: mustTransport(t, transportConfig{ProxyURL: proxy.URL})}).Get("http://api.cloudflare.com/client/v4/zones")
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, "http://api.cloudflare.com/client/v4/zones", proxied)
+	})
+
+	t.Run("fails requests after the overall timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{Transport: mustTransport(t, transportConfig{Timeout: 50 * time.Millisecond})}
+		// the deadline starts with the first request, not the transport.
+		time.Sleep(100 * time.Millisecond)
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		time.Sleep(100 * time.Millisecond)
+		_, err = client.Get(server.URL)
+		assert.ErrorContains(t, err, "overall timeout exceeded")
+	})
+}
+
+func mustTransport(t *testing.T, cfg transportConfig) http.RoundTripper {
+	t.Helper()
+	rt, err := newHTTPTransport(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rt
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// defaultProviderConstraint is the version constraint of the Cloudflare
+// provider a scratch workspace is bootstrapped with when no version is
+// requested.
+const defaultProviderConstraint = "~> 5.0"
+
+// pluginCacheDirEnvVar is the environment variable Terraform reads the plugin
+// cache directory from.
+const pluginCacheDirEnvVar = "TF_PLUGIN_CACHE_DIR"
+
+// terraformWorkspace is a Terraform working directory with the Cloudflare
+// provider installed.
+type terraformWorkspace struct {
+	tf           *tfexec.Terraform
+	registryPath string
+	version      *version.Version
+
+	// scratch is set when the workspace was bootstrapped and is removed by
+	// close.
+	scratch string
+}
+
+// close removes the workspace if it was bootstrapped.
+func (w *terraformWorkspace) close() {
+	if w == nil || w.scratch == "" {
+		return
+	}
+	if err := os.RemoveAll(w.scratch); err != nil {
+		log.WithError(err).Warnf("failed to remove the Terraform workspace %s", w.scratch)
+	}
+}
+
+// openTerraformWorkspace finds or installs Terraform and returns the working
+// directory of `--terraform-install-path`. When the Cloudflare provider isn't
+// initialised in it, or constraint is set, a scratch workspace that requires
+// the provider matching constraint is bootstrapped instead. The workspace must
+// be closed once it is no longer needed.
+func openTerraformWorkspace(ctx context.Context, constraint string) (*terraformWorkspace, error) {
+	execPath, err := findOrInstallTerraform(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not find or install Terraform: %w", err)
+	}
+
+	if constraint == "" {
+		workingDir := viper.GetString("terraform-install-path")
+		log.WithFields(logrus.Fields{
+			"directory": workingDir,
+		}).Debug("initializing Terraform")
+
+		tf, err := tfexec.NewTerraform(workingDir, execPath)
+		if err != nil {
+			return nil, err
+		}
+		_, providerVersions, err := tf.Version(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve terraform and provider version information: %w", err)
+		}
+		if registryPath, v := cloudflareProviderVersion(providerVersions); v != nil {
+			return &terraformWorkspace{tf: tf, registryPath: registryPath, version: v}, nil
+		}
+
+		log.WithFields(logrus.Fields{
+			"directory":            workingDir,
+			"available_registries": providerVersions,
+		}).Info("the Cloudflare provider isn't initialised in the Terraform working directory, bootstrapping a workspace")
+		constraint = defaultProviderConstraint
+	}
+
+	return bootstrapTerraformWorkspace(ctx, execPath, constraint)
+}
+
+// bootstrapTerraformWorkspace creates a scratch workspace that requires the
+// Cloudflare provider matching constraint and initialises it. Providers are
+// installed from `--terraform-plugin-dir` and cached in
+// `--terraform-plugin-cache-dir` when they are set.
+func bootstrapTerraformWorkspace(ctx context.Context, execPath, constraint string) (ws *terraformWorkspace, err error) {
+	if _, err := version.NewConstraint(constraint); err != nil {
+		return nil, fmt.Errorf("invalid provider version constraint %q: %w", constraint, err)
+	}
+
+	dir, err := os.MkdirTemp("", "cf-terraforming-workspace-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Terraform workspace: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(dir)
+		}
+	}()
+
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(workspaceConfig(constraint)), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write Terraform workspace configuration: %w", err)
+	}
+
+	tf, err := tfexec.NewTerraform(dir, execPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheDir := viper.GetString("terraform-plugin-cache-dir"); cacheDir != "" {
+		if err := os.MkdirAll(cacheDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create plugin cache directory: %w", err)
+		}
+		if err := tf.SetEnv(terraformEnv(map[string]string{pluginCacheDirEnvVar: cacheDir})); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []tfexec.InitOption{tfexec.Backend(false)}
+	pluginDir := viper.GetString("terraform-plugin-dir")
+	if pluginDir != "" {
+		opts = append(opts, tfexec.PluginDir(pluginDir))
+	}
+
+	log.WithFields(logrus.Fields{
+		"directory":  dir,
+		"constraint": constraint,
+		"plugin_dir": pluginDir,
+	}).Info("initializing Terraform workspace")
+	if err := tf.Init(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("failed to initialise Terraform workspace: %w", err)
+	}
+
+	_, providerVersions, err := tf.Version(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve terraform and provider version information: %w", err)
+	}
+	registryPath, v := cloudflareProviderVersion(providerVersions)
+	if v == nil {
+		return nil, errors.New("the Cloudflare provider wasn't installed in the bootstrapped Terraform workspace")
+	}
+	return &terraformWorkspace{tf: tf, registryPath: registryPath, version: v, scratch: dir}, nil
+}
+
+// workspaceConfig returns the configuration of a workspace that requires the
+// Cloudflare provider matching constraint.
+func workspaceConfig(constraint string) string {
+	return fmt.Sprintf(`terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = %q
+    }
+  }
+}
+`, constraint)
+}
+
+// cloudflareProviderVersion returns the registry path and version of the
+// Cloudflare provider in versions, or nil if it isn't one of them.
+func cloudflareProviderVersion(versions map[string]*version.Version) (string, *version.Version) {
+	paths := make([]string, 0, len(versions))
+	for path := range versions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, cloudflareProviderPath) {
+			return path, versions[path]
+		}
+	}
+	return "", nil
+}
+
+// terraformEnv returns the environment of this process with extra added, minus
+// the variables tfexec manages itself.
+func terraformEnv(extra map[string]string) map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	for _, k := range tfexec.ProhibitedEnv(env) {
+		delete(env, k)
+	}
+	for k, v := range extra {
+		env[k] = v
+	}
+	return env
+}
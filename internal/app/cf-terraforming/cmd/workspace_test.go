@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setRootFlag sets a flag of the root command for the duration of the test.
+func setRootFlag(t *testing.T, name, value string) {
+	t.Helper()
+	restoreFlag(t, rootCmd.PersistentFlags(), name)
+	flag := rootCmd.PersistentFlags().Lookup(name)
+	require.NoError(t, flag.Value.Set(value))
+	flag.Changed = true
+}
+
+// restoreFlag resets the flag name in flags to its current value once the
+// test is done.
+func restoreFlag(t *testing.T, flags *pflag.FlagSet, name string) {
+	t.Helper()
+	flag := flags.Lookup(name)
+	require.NotNil(t, flag)
+	changed := flag.Changed
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		previous := slice.GetSlice()
+		t.Cleanup(func() {
+			_ = slice.Replace(previous)
+			flag.Changed = changed
+		})
+		return
+	}
+	previous := flag.Value.String()
+	t.Cleanup(func() {
+		_ = flag.Value.Set(previous)
+		flag.Changed = changed
+	})
+}
+
+// writeFakeTerraform creates a stand-in for the Terraform binary that reports
+// the Cloudflare provider once `terraform init` has been run in a directory,
+// and records the arguments and configuration `init` is run with in logDir.
+func writeFakeTerraform(t *testing.T, logDir string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake Terraform binary requires a POSIX shell")
+	}
+	script := fmt.Sprintf(`#!/bin/sh
+case "$1" in
+version)
+  if [ -f .terraform.lock.hcl ]; then
+    echo '{"terraform_version":"1.9.0","platform":"linux_amd64","provider_selections":{"registry.terraform.io/cloudflare/cloudflare":"5.1.0"},"terraform_outdated":false}'
+  else
+    echo '{"terraform_version":"1.9.0","platform":"linux_amd64","provider_selections":{},"terraform_outdated":false}'
+  fi
+  ;;
+init)
+  echo "$@" > %[1]s/args
+  echo "$TF_PLUGIN_CACHE_DIR" > %[1]s/cache
+  cp main.tf %[1]s/main.tf
+  touch .terraform.lock.hcl
+  ;;
+esac
+`, logDir)
+	path := filepath.Join(t.TempDir(), "terraform")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700))
+	return path
+}
+
+func TestOpenTerraformWorkspace(t *testing.T) {
+	logDir := t.TempDir()
+	setRootFlag(t, "terraform-binary-path", writeFakeTerraform(t, logDir))
+
+	t.Run("initialised", func(t *testing.T) {
+		workingDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform.lock.hcl"), nil, 0600))
+		setRootFlag(t, "terraform-install-path", workingDir)
+
+		ws, err := openTerraformWorkspace(context.Background(), "")
+		require.NoError(t, err)
+		defer ws.close()
+		assert.Equal(t, "registry.terraform.io/cloudflare/cloudflare", ws.registryPath)
+		assert.Equal(t, "5.1.0", ws.version.String())
+		assert.Empty(t, ws.scratch)
+		assert.NoFileExists(t, filepath.Join(logDir, "args"), "an initialised working directory must be used as is")
+	})
+
+	t.Run("bootstrapped", func(t *testing.T) {
+		setRootFlag(t, "terraform-install-path", t.TempDir())
+		pluginDir, cacheDir := t.TempDir(), filepath.Join(t.TempDir(), "cache")
+		setRootFlag(t, "terraform-plugin-dir", pluginDir)
+		setRootFlag(t, "terraform-plugin-cache-dir", cacheDir)
+
+		ws, err := openTerraformWorkspace(context.Background(), "")
+		require.NoError(t, err)
+		assert.Equal(t, "5.1.0", ws.version.String())
+		assert.DirExists(t, ws.scratch)
+
+		config, err := os.ReadFile(filepath.Join(logDir, "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, workspaceConfig(defaultProviderConstraint), string(config))
+		assert.Contains(t, string(config), `version = "~> 5.0"`)
+
+		args, err := os.ReadFile(filepath.Join(logDir, "args"))
+		require.NoError(t, err)
+		assert.Contains(t, string(args), "-backend=false")
+		assert.Contains(t, string(args), "-plugin-dir="+pluginDir)
+
+		cache, err := os.ReadFile(filepath.Join(logDir, "cache"))
+		require.NoError(t, err)
+		assert.Equal(t, cacheDir+"\n", string(cache))
+		assert.DirExists(t, cacheDir)
+
+		ws.close()
+		assert.NoDirExists(t, ws.scratch)
+	})
+
+	t.Run("requested version", func(t *testing.T) {
+		ws, err := openTerraformWorkspace(context.Background(), "= 5.1.0")
+		require.NoError(t, err)
+		defer ws.close()
+		assert.NotEmpty(t, ws.scratch, "a requested version is always bootstrapped")
+
+		config, err := os.ReadFile(filepath.Join(logDir, "main.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(config), `version = "= 5.1.0"`)
+
+		_, err = openTerraformWorkspace(context.Background(), "five")
+		assert.ErrorContains(t, err, `invalid provider version constraint "five"`)
+	})
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// apiCache is an on-disk cache of API response bodies. Entries are keyed by
+// the endpoint and a fingerprint of the credentials used to fetch them, and
+// are encrypted with AES-GCM using a key derived from those credentials so
+// that secrets in responses are never stored in plain text and entries can
+// only be read back with the same credentials.
+type apiCache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+	baseURL string
+	aead    cipher.AEAD
+	log     *logrus.Logger
+}
+
+type apiCacheEntry struct {
+	StoredAt time.Time `json:"stored_at"`
+	Endpoint string    `json:"endpoint"`
+	Body     []byte    `json:"body"`
+}
+
+// newAPICache returns a cache rooted at dir for the given credential. When
+// refresh is set, entries are never read but are still written so that the
+// following runs benefit from the fresh responses. Unusable entries are
+// logged to log.
+func newAPICache(dir string, ttl time.Duration, refresh bool, baseURL, credential string, log *logrus.Logger) (*apiCache, error) {
+	if credential == "" {
+		return nil, errors.New("the response cache requires credentials to derive its encryption key")
+	}
+
+	fingerprint := sha256.Sum256([]byte("cf-terraforming cache fingerprint\x00" + credential))
+	key := sha256.Sum256([]byte("cf-terraforming cache key\x00" + credential))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = filepath.Join(dir, hex.EncodeToString(fingerprint[:8]))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &apiCache{dir: dir, ttl: ttl, refresh: refresh, baseURL: baseURL, aead: aead, log: log}, nil
+}
+
+func (c *apiCache) path(endpoint string) string {
+	sum := sha256.Sum256([]byte(c.baseURL + "\x00" + endpoint))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".bin")
+}
+
+// get returns the cached body for endpoint if present and not expired.
+func (c *apiCache) get(endpoint string) ([]byte, bool) {
+	if c == nil || c.refresh {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(endpoint))
+	if err != nil {
+		return nil, false
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, false
+	}
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], []byte(endpoint))
+	if err != nil {
+		c.log.WithFields(logrus.Fields{
+			"endpoint": endpoint,
+		}).Debug("ignoring unreadable cache entry")
+		return nil, false
+	}
+
+	var entry apiCacheEntry
+	if err := json.Unmarshal(plaintext, &entry); err != nil || entry.Endpoint != endpoint {
+		return nil, false
+	}
+	if c.ttl > 0 && time.Since(entry.StoredAt) > c.ttl {
+		return nil, false
+	}
+
+	c.log.WithFields(logrus.Fields{
+		"endpoint": endpoint,
+		"age":      time.Since(entry.StoredAt).Round(time.Second).String(),
+	}).Debug("using cached API response")
+	return entry.Body, true
+}
+
+// put stores body for endpoint. Failures are logged rather than returned as
+// the cache is only an optimisation.
+func (c *apiCache) put(endpoint string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	plaintext, err := json.Marshal(apiCacheEntry{StoredAt: time.Now().UTC(), Endpoint: endpoint, Body: body})
+	if err != nil {
+		c.log.Warnf("failed to encode cache entry: %s", err)
+		return
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		c.log.Warnf("failed to generate cache nonce: %s", err)
+		return
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(endpoint))
+
+	if err := os.WriteFile(c.path(endpoint), sealed, 0600); err != nil {
+		c.log.Warnf("failed to write cache entry: %s", err)
+	}
+}
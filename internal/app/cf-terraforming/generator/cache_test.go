@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPICache(t *testing.T) {
+	dir := t.TempDir()
+	endpoint := "/zones/" + cloudflareTestZoneID + "/dns_records"
+	body := []byte(`{"result":[{"id":"1","tunnel_secret":"c2VjcmV0"}]}`)
+
+	c, err := newAPICache(dir, time.Hour, false, "", "token:abc", logrus.New())
+	assert.NoError(t, err)
+
+	_, ok := c.get(endpoint)
+	assert.False(t, ok, "empty cache should miss")
+
+	c.put(endpoint, body)
+	got, ok := c.get(endpoint)
+	assert.True(t, ok)
+	assert.Equal(t, body, got)
+
+	t.Run("entries are encrypted", func(t *testing.T) {
+		files, _ := filepath.Glob(filepath.Join(dir, "*", "*.bin"))
+		assert.Len(t, files, 1)
+		raw, _ := os.ReadFile(files[0])
+		assert.False(t, bytes.Contains(raw, []byte("c2VjcmV0")))
+		assert.False(t, bytes.Contains(raw, []byte("dns_records")))
+
+		info, _ := os.Stat(files[0])
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("other credentials can't read entries", func(t *testing.T) {
+		other, err := newAPICache(dir, time.Hour, false, "", "token:xyz", logrus.New())
+		assert.NoError(t, err)
+		_, ok := other.get(endpoint)
+		assert.False(t, ok)
+	})
+
+	t.Run("entries for other base URLs are separate", func(t *testing.T) {
+		other, err := newAPICache(dir, time.Hour, false, "http://localhost:8080/client/v4", "token:abc", logrus.New())
+		assert.NoError(t, err)
+		_, ok := other.get(endpoint)
+		assert.False(t, ok)
+	})
+
+	t.Run("expired entries are ignored", func(t *testing.T) {
+		expired, err := newAPICache(dir, time.Nanosecond, false, "", "token:abc", logrus.New())
+		assert.NoError(t, err)
+		time.Sleep(time.Millisecond)
+		_, ok := expired.get(endpoint)
+		assert.False(t, ok)
+	})
+
+	t.Run("refresh bypasses the cache", func(t *testing.T) {
+		refresh, err := newAPICache(dir, time.Hour, true, "", "token:abc", logrus.New())
+		assert.NoError(t, err)
+		_, ok := refresh.get(endpoint)
+		assert.False(t, ok)
+	})
+
+	t.Run("credentials are required", func(t *testing.T) {
+		_, err := newAPICache(dir, time.Hour, false, "", "", logrus.New())
+		assert.Error(t, err)
+	})
+}
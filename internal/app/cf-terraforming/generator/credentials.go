@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"strings"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+)
+
+// credentials authenticate the requests of a run to the Cloudflare API.
+type credentials struct {
+	apiToken string
+	apiKey   string
+	apiEmail string
+
+	// originCAKey is the Origin CA key (user service key) used to
+	// authenticate requests to the `/certificates` endpoints.
+	originCAKey string
+}
+
+// cacheKey returns the credential the response cache key is derived from.
+func (c credentials) cacheKey() string {
+	if c.apiToken != "" {
+		return "token:" + c.apiToken
+	}
+	if c.apiKey != "" {
+		return "key:" + c.apiEmail + ":" + c.apiKey
+	}
+	if c.originCAKey != "" {
+		return "origin-ca-key:" + c.originCAKey
+	}
+	return ""
+}
+
+// isOriginCAEndpoint reports whether endpoint is one of the Origin CA
+// certificate endpoints that accept an Origin CA key.
+func isOriginCAEndpoint(endpoint string) bool {
+	rest, ok := strings.CutPrefix(endpoint, "/certificates")
+	return ok && (rest == "" || strings.HasPrefix(rest, "/") || strings.HasPrefix(rest, "?"))
+}
+
+// endpointRequestOptions returns the per request options for endpoint. When an
+// Origin CA key is configured, it replaces the regular credentials for the
+// Origin CA endpoints only.
+func (rc *runContext) endpointRequestOptions(endpoint string) []option.RequestOption {
+	if rc.credentials.originCAKey == "" || !isOriginCAEndpoint(endpoint) {
+		return nil
+	}
+	return []option.RequestOption{
+		option.WithHeaderDel("Authorization"),
+		option.WithHeaderDel("X-Auth-Key"),
+		option.WithHeaderDel("X-Auth-Email"),
+		option.WithUserServiceKey(rc.credentials.originCAKey),
+	}
+}
+
+// originCAClientV0 returns the cloudflare-go v0 client to use for the Origin
+// CA endpoints, which authenticates with the Origin CA key when one is
+// configured.
+func (rc *runContext) originCAClientV0() *cfv0.API {
+	if rc.credentials.originCAKey == "" {
+		return rc.apiV0
+	}
+	client := *rc.apiV0
+	client.APIUserServiceKey = rc.credentials.originCAKey
+	client.SetAuthType(cfv0.AuthUserService)
+	return &client
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginCAKeyOnlyForCertificateEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/client/v4/certificates") {
+			assert.Equal(t, "origin-ca-key", r.Header.Get("X-Auth-User-Service-Key"))
+			assert.Empty(t, r.Header.Get("Authorization"))
+			assert.Equal(t, cloudflareTestZoneID, r.URL.Query().Get("zone_id"))
+		} else {
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			assert.Empty(t, r.Header.Get("X-Auth-User-Service-Key"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[]}`))
+	}))
+	defer server.Close()
+
+	rc := &runContext{
+		zoneID:      cloudflareTestZoneID,
+		credentials: credentials{apiToken: "test-token", originCAKey: "origin-ca-key"},
+		log:         logrus.New(),
+	}
+	var err error
+	rc.apiV0, rc.api, err = newAPIClients(server.Client(), server.URL+"/client/v4", rc.credentials, false)
+	assert.NoError(t, err)
+
+	endpoint := rc.resourceEndpoint("cloudflare_origin_ca_certificate")
+	assert.Equal(t, "/certificates?zone_id="+cloudflareTestZoneID, endpoint)
+
+	for _, e := range []string{endpoint, rc.resourceEndpoint("cloudflare_dns_record")} {
+		_, status, err := rc.probeEndpoint(context.Background(), e)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	_, err = rc.originCAClientV0().ListOriginCACertificates(context.Background(), cfv0.ListOriginCertificatesParams{ZoneID: rc.zoneID})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-token", rc.apiV0.APIToken, "the shared client must not be modified")
+}
+
+func TestIsOriginCAEndpoint(t *testing.T) {
+	assert.True(t, isOriginCAEndpoint("/certificates"))
+	assert.True(t, isOriginCAEndpoint("/certificates?zone_id=abc"))
+	assert.True(t, isOriginCAEndpoint("/certificates/abc"))
+	assert.False(t, isOriginCAEndpoint("/certificates_other"))
+	assert.False(t, isOriginCAEndpoint("/zones/abc/ssl/certificate_packs"))
+}
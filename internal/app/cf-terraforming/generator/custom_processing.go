@@ -0,0 +1,267 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/tidwall/gjson"
+)
+
+func unMarshallJSONStructData(modifiedJSONString string) ([]interface{}, error) {
+	var data interface{}
+	err := json.Unmarshal([]byte(modifiedJSONString), &data)
+	if err != nil {
+		return nil, err
+	}
+	if dataSlice, ok := data.([]interface{}); ok {
+		return dataSlice, nil
+	}
+	return []interface{}{data}, nil
+}
+
+func (rc *runContext) getAPIResponse(ctx context.Context, result *http.Response, rType string, pathParams []string, endpoints ...string) ([]interface{}, error) {
+	var allResults []interface{}
+	handler := resourceHandlerFor(rType)
+	style := resourceToEndpoint[rType].pagination
+
+	for i, baseEndpoint := range endpoints {
+		page := 1
+		totalPages := 1
+		cursor := ""
+		param := ""
+		if len(pathParams) > 0 {
+			param = pathParams[i]
+		}
+
+		for {
+			var endpoint string
+			switch {
+			case cursor != "":
+				endpoint = appendQueryParam(baseEndpoint, "cursor", url.QueryEscape(cursor))
+			case page > 1:
+				endpoint = appendQueryParam(baseEndpoint, "page", strconv.Itoa(page))
+			default:
+				// no pagination params for the first request
+				endpoint = baseEndpoint
+			}
+
+			body, err := rc.fetchEndpoint(ctx, result, rType, endpoint)
+			if err != nil {
+				if isNotFound(err) {
+					rc.log.WithFields(logrus.Fields{
+						"resource": rType,
+						"endpoint": endpoint,
+					}).Debug("no resources found")
+					return nil, err
+				}
+				return nil, fmt.Errorf("failed to fetch API endpoint: %w", err)
+			}
+
+			resultVal := gjson.Get(string(body), "result")
+			if resultVal.Type == gjson.Null {
+				rc.log.WithFields(logrus.Fields{
+					"resource": rType,
+					"endpoint": endpoint,
+				}).Debug("no result found")
+				return nil, errNoResult
+			}
+
+			modifiedJSON := handler.ModifyPayload(resultVal)
+			jsonStructData, err := unMarshallJSONStructData(modifiedJSON)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+			}
+
+			rc.transformResources(rType, &jsonStructData, param)
+			allResults = append(allResults, jsonStructData...)
+
+			if style == paginationNone {
+				break
+			}
+
+			// Cursor based pagination takes precedence over page numbers as the
+			// endpoints that use it don't return a usable `total_pages`.
+			if style != paginationPage {
+				if next := nextPageCursor(string(body)); next != "" && next != cursor {
+					rc.log.WithFields(logrus.Fields{
+						"resource": rType,
+						"endpoint": baseEndpoint,
+						"cursor":   next,
+					}).Debug("following cursor to next page")
+					cursor = next
+					continue
+				}
+			}
+			if cursor != "" || style == paginationCursor {
+				break
+			}
+
+			if page == 1 {
+				totalPagesVal := gjson.Get(string(body), "result_info.total_pages")
+				if totalPagesVal.Exists() {
+					totalPages = int(totalPagesVal.Int())
+				}
+			}
+
+			if page >= totalPages {
+				break
+			}
+			page++
+		}
+	}
+	return allResults, nil
+}
+
+// nextPageCursor returns the cursor for the next page of results when the
+// endpoint uses cursor based pagination. The API exposes this either as
+// `result_info.cursors.after` (lists, items) or `result_info.cursor` (R2 and
+// some Zero Trust listings). An empty string means there are no more pages.
+func nextPageCursor(body string) string {
+	if after := gjson.Get(body, "result_info.cursors.after"); after.Type == gjson.String {
+		return after.String()
+	}
+	if cursor := gjson.Get(body, "result_info.cursor"); cursor.Type == gjson.String {
+		return cursor.String()
+	}
+	return ""
+}
+
+// appendQueryParam adds a query parameter to the endpoint, respecting any
+// query string that is already present.
+func appendQueryParam(endpoint, key, value string) string {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s%s=%s", endpoint, sep, key, value)
+}
+
+// fetchEndpoint returns the response body for a single API request. When
+// `--from-snapshot` is in use the body is read from the snapshot directory
+// instead and no request is made. Otherwise the response cache is consulted
+// before calling the API. When a snapshot is being taken, the response is
+// also written to the snapshot directory.
+func (rc *runContext) fetchEndpoint(ctx context.Context, result *http.Response, rType, endpoint string) ([]byte, error) {
+	if rc.fromSnapshotDir != "" {
+		return readSnapshot(rc.fromSnapshotDir, rType, endpoint)
+	}
+
+	body, ok := rc.cache.get(endpoint)
+	if !ok {
+		err := rc.api.Get(ctx, endpoint, nil, &result, rc.endpointRequestOptions(endpoint)...)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err = io.ReadAll(result.Body)
+		if err != nil {
+			return nil, err
+		}
+		rc.cache.put(endpoint, body)
+	}
+
+	if rc.snapshotDir != "" {
+		if err := writeSnapshot(rc.snapshotDir, rType, endpoint, body); err != nil {
+			return nil, fmt.Errorf("failed to write snapshot for %s: %w", endpoint, err)
+		}
+	}
+
+	return body, nil
+}
+
+// errNoResult is returned when an endpoint responds without a `result`.
+var errNoResult = errors.New("no result found")
+
+// isNotFound reports whether err means the endpoint has no resources, either
+// because the API responded with a 404 or because the snapshot doesn't contain
+// the endpoint.
+func isNotFound(err error) bool {
+	var apierr *cloudflare.Error
+	if errors.As(err, &apierr) && apierr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// isEmpty reports whether err only means that there is nothing to generate
+// for a resource type, rather than that fetching it failed.
+func isEmpty(err error) bool {
+	return isNotFound(err) || errors.Is(err, errNoResult)
+}
+
+// resourceEndpoint returns the API endpoint used to fetch all resources of
+// rType with the account and zone placeholders replaced. An empty string is
+// returned when the resource has neither a `list` nor a `get` operation.
+func (rc *runContext) resourceEndpoint(rType string) string {
+	e := resourceToEndpoint[rType]
+	endpoint := e.fetchEndpoint()
+
+	// if we encounter a combined endpoint, we need to rewrite to use the correct
+	// endpoint depending on what parameters are being provided.
+	if e.scope == scopeAccountOrZone {
+		if rc.accountID != "" {
+			endpoint = strings.Replace(endpoint, "/{accounts_or_zones}/{account_or_zone_id}/", "/accounts/{account_id}/", 1)
+		} else {
+			endpoint = strings.Replace(endpoint, "/{accounts_or_zones}/{account_or_zone_id}/", "/zones/{zone_id}/", 1)
+		}
+	}
+
+	// replace the URL placeholders with the actual values we have.
+	placeholderReplacer := strings.NewReplacer("{account_id}", rc.accountID, "{zone_id}", rc.zoneID)
+	endpoint = placeholderReplacer.Replace(endpoint)
+
+	// Origin CA certificates are listed per zone using a query parameter.
+	if rType == "cloudflare_origin_ca_certificate" && rc.zoneID != "" {
+		endpoint = appendQueryParam(endpoint, "zone_id", rc.zoneID)
+	}
+	return endpoint
+}
+
+func isSupportedPathParam(resources []string, rType string) bool {
+	return SupportsParentIDs(rType) && slices.Contains(resources, rType)
+}
+
+func addAttributeKeyValue(response *[]interface{}, resourceCount int, key string, value string) {
+	for i := 0; i < resourceCount; i++ {
+		(*response)[i].(map[string]interface{})[key] = value
+	}
+}
+
+func remapProperty(response *[]interface{}, resourceCount int, responseProperty string, remappedProperty string) {
+	for i := 0; i < resourceCount; i++ {
+		prop, ok := (*response)[i].(map[string]interface{})[responseProperty]
+		if !ok {
+			continue
+		}
+		(*response)[i].(map[string]interface{})[remappedProperty] = prop
+	}
+}
+
+func denestResponses(response *[]interface{}, resourceCount int, nestedAttributeName string) {
+	finalResponse := make([]interface{}, 0)
+	r := *response
+	for i := 0; i < resourceCount; i++ {
+		nestedObjects, ok := r[i].(map[string]interface{})[nestedAttributeName].([]interface{})
+		if !ok {
+			continue
+		}
+		finalResponse = append(finalResponse, nestedObjects...)
+	}
+	*response = make([]interface{}, len(finalResponse))
+	for i := range finalResponse {
+		(*response)[i] = finalResponse[i]
+	}
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+
+	"github.com/dnaeon/go-vcr/recorder"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAPIResponse_CursorPagination(t *testing.T) {
+	r, err := recorder.New("../../../../testdata/cloudflare/v5/cloudflare_list_item_cursor_pagination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := r.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	rc := &runContext{log: logrus.New(), api: cloudflare.NewClient(option.WithHTTPClient(&http.Client{Transport: r}))}
+
+	endpoint := "/accounts/" + cloudflareTestAccountID + "/rules/lists/2a4b8b2017aa4b3cb9e1151b52c81d22/items"
+	var result *http.Response
+	results, err := rc.getAPIResponse(context.Background(), result, "cloudflare_list_item", nil, endpoint)
+	assert.NoError(t, err)
+
+	comments := make([]string, 0, len(results))
+	for _, res := range results {
+		comments = append(comments, res.(map[string]interface{})["comment"].(string))
+	}
+	assert.Equal(t, []string{"first page", "second page", "third page"}, comments)
+}
+
+func TestNextPageCursor(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want string
+	}{
+		"cursors.after":       {body: `{"result_info":{"cursors":{"after":"abc"}}}`, want: "abc"},
+		"cursor":              {body: `{"result_info":{"cursor":"def"}}`, want: "def"},
+		"empty cursor":        {body: `{"result_info":{"cursor":""}}`, want: ""},
+		"only cursors.before": {body: `{"result_info":{"cursors":{"before":"abc"}}}`, want: ""},
+		"page based":          {body: `{"result_info":{"page":1,"total_pages":3}}`, want: ""},
+		"no result_info":      {body: `{"result":[]}`, want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, nextPageCursor(tc.body))
+		})
+	}
+}
@@ -0,0 +1,1560 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// deprecatedResources are resource types that are generated with a warning
+// as the provider no longer supports them.
+var deprecatedResources = []string{"cloudflare_firewall_rule"}
+
+// errNotSupported is returned for resource types that can't be generated
+// with v4 of the provider.
+var errNotSupported = errors.New("not yet supported")
+
+// generateResourceTypes fetches every resource type in resources and writes
+// the generated configuration to out. Notices, such as resource types without
+// any resources, are written to errOut. Resource types that couldn't be fetched
+// are skipped and returned as a joined error once the others are generated.
+// The import blocks for the generated resources are returned as well.
+func (rc *runContext) generateResourceTypes(ctx context.Context, out, errOut io.Writer, s *tfjson.ProviderSchema, resources []string) ([]ImportBlock, error) {
+	var err error
+	var failures []error
+	var imports []ImportBlock
+	for _, resourceType := range resources {
+		if err := ctx.Err(); err != nil {
+			failures = append(failures, err)
+			break
+		}
+
+		r := s.ResourceSchemas[resourceType]
+		rc.log.WithFields(logrus.Fields{
+			"resource": resourceType,
+		}).Debug("reading and building resource")
+		if (r != nil && r.Block != nil && r.Block.Deprecated) || slices.Contains(deprecatedResources, resourceType) {
+			rc.log.Warnf("resource %s is deprecated. The terraform config might not be generated.", resourceType)
+		}
+
+		// Initialise `resourceCount` outside of the switch for supported resources
+		// to allow it to be referenced further down in the loop that outputs the
+		// newly generated resources.
+		resourceCount := 0
+		var jsonStructData []interface{}
+
+		// The ruleset API has many gotchas that are accounted for in how we build
+		// the 'response' object that feeds into the HCL generation, and it's difficult
+		// to ensure the same compatability using the generated SDK.
+		useOldSDK := resourceType == "cloudflare_ruleset"
+
+		if rc.fromSnapshotDir != "" && (useOldSDK || !rc.isV5()) {
+			rc.log.WithFields(logrus.Fields{
+				"resource": resourceType,
+			}).Warn("resource is not supported by snapshots")
+			continue
+		}
+
+		if rc.isV5() && !useOldSDK {
+			ids, err := rc.parentIDs(resources, resourceType)
+			if err != nil {
+				failures = append(failures, err)
+				continue
+			}
+
+			if resourceToEndpoint[resourceType].fetchEndpoint() == "" {
+				rc.log.WithFields(logrus.Fields{
+					"resource": resourceType,
+				}).Warn("Unsupported terraform v5 provider resource")
+				continue
+			}
+
+			jsonStructData, err = rc.fetchResources(ctx, resourceType, ids)
+			if err != nil {
+				rc.log.Infof("error getting API response for resource %s: %s", resourceType, err)
+				if !isEmpty(err) {
+					failures = append(failures, fmt.Errorf("%s: %w", resourceType, err))
+				}
+				continue
+			}
+			resourceCount = len(jsonStructData)
+		} else {
+			jsonStructData, resourceCount, err = rc.fetchV4Resources(ctx, resourceType)
+			if errors.Is(err, errNotSupported) {
+				fmt.Fprintf(errOut, "%q is not yet supported for automatic generation\n", resourceType)
+				continue
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", resourceType, err))
+				continue
+			}
+		}
+		rc.log.WithFields(logrus.Fields{
+			"count":    resourceCount,
+			"resource": resourceType,
+		}).Debug("generating resource output")
+
+		// transformers run once all the built-in changes have been made.
+		if rc.isV5() && len(jsonStructData) > 0 {
+			transformed, err := rc.applyTransformer(ctx, resourceType, jsonStructData)
+			if err != nil {
+				rc.log.Error(err)
+				failures = append(failures, err)
+				continue
+			}
+			jsonStructData = transformed
+			resourceCount = len(jsonStructData)
+		}
+
+		if filters := rc.filters[resourceType]; len(filters) > 0 {
+			jsonStructData = filterResources(jsonStructData, filters)
+			resourceCount = len(jsonStructData)
+		}
+
+		// If we don't have any resources to generate, move on to the next type.
+		if resourceCount == 0 {
+			fmt.Fprintf(errOut, "no resources of type %q found to generate\n", resourceType)
+			continue
+		}
+
+		if r == nil {
+			failures = append(failures, fmt.Errorf("failed to find %q in the initialized provider schema", resourceType))
+			continue
+		}
+
+		var sensitive *sensitiveVariables
+		if rc.sensitiveVariables {
+			sensitive = newSensitiveVariables(rc.log)
+		}
+
+		f := hclwrite.NewEmptyFile()
+		rootBody := f.Body()
+		for i := 0; i < resourceCount; i++ {
+			structData := jsonStructData[i].(map[string]interface{})
+			if rc.pii != nil {
+				rc.pii.redact(resourceType, structData)
+			}
+			rc.createStubFiles(structData)
+
+			resourceID := ""
+			if os.Getenv("USE_STATIC_RESOURCE_IDS") == "true" {
+				if resourceCount == 1 {
+					resourceID = "terraform_managed_resource"
+				} else {
+					resourceID = fmt.Sprintf("terraform_managed_resource_%d", i)
+				}
+			} else {
+				id := ""
+				switch structData["id"].(type) {
+				case float64:
+					id = fmt.Sprintf("%f", structData["id"].(float64))
+				default:
+					if structData["id"] == nil {
+						if rc.accountID != "" {
+							id = rc.accountID
+						}
+
+						if rc.zoneID != "" {
+							id = rc.zoneID
+						}
+					} else {
+						id = structData["id"].(string)
+					}
+				}
+				resourceID = fmt.Sprintf("terraform_managed_resource_%s_%d", id, i)
+			}
+			if address, ok := rc.importAddress(resourceType, rc.importResourceID(resourceType, structData), resourceToEndpoint[resourceType].importID); ok {
+				imports = append(imports, ImportBlock{To: resourceType + "." + resourceID, ID: address})
+			}
+			resource := rootBody.AppendNewBlock("resource", []string{resourceType, resourceID}).Body()
+			if sensitive != nil {
+				sensitive.replaceNested(resourceType, resourceID, r.Block, structData)
+			}
+
+			sortedBlockAttributes := make([]string, 0, len(r.Block.Attributes))
+			for k := range r.Block.Attributes {
+				sortedBlockAttributes = append(sortedBlockAttributes, k)
+			}
+			sort.Strings(sortedBlockAttributes)
+
+			// Block attributes are for any attributes where assignment is involved.
+			for _, attrName := range sortedBlockAttributes {
+				// Don't bother outputting the ID for the resource as that is only for
+				// internal use (such as importing state).
+				if attrName == "id" {
+					continue
+				}
+
+				// No need to output computed attributes that are also not
+				// optional.
+				if r.Block.Attributes[attrName].Computed && !r.Block.Attributes[attrName].Optional {
+					continue
+				}
+				if attrName == "account_id" && rc.accountID != "" {
+					rc.writeAttrLine(attrName, rc.accountID, "", resource)
+					continue
+				}
+
+				if attrName == "zone_id" && rc.zoneID != "" && rc.accountID == "" {
+					rc.writeAttrLine(attrName, rc.zoneID, "", resource)
+					continue
+				}
+
+				if sensitive != nil && r.Block.Attributes[attrName].Sensitive {
+					sensitive.replace(resource, resourceType, resourceID, attrName, r.Block.Attributes[attrName], structData[attrName])
+					delete(structData, attrName)
+					continue
+				}
+
+				ty := r.Block.Attributes[attrName].AttributeType
+				switch {
+				case ty.IsPrimitiveType():
+					switch ty {
+					case cty.String, cty.Bool, cty.Number:
+						rc.writeAttrLine(attrName, structData[attrName], "", resource)
+						delete(structData, attrName)
+					default:
+						rc.log.Debugf("unexpected primitive type %q", ty.FriendlyName())
+					}
+				case ty.IsCollectionType():
+					switch {
+					case ty.IsListType(), ty.IsSetType(), ty.IsMapType():
+						rc.writeAttrLine(attrName, structData[attrName], "", resource)
+						delete(structData, attrName)
+					default:
+						rc.log.Debugf("unexpected collection type %q", ty.FriendlyName())
+					}
+				case ty.IsTupleType():
+					fmt.Printf("tuple found. attrName %s\n", attrName)
+				case ty.IsObjectType():
+					fmt.Printf("object found. attrName %s\n", attrName)
+				default:
+					rc.log.Debugf("attribute %q has not been generated", attrName)
+				}
+			}
+
+			rc.processBlocks(r.Block, jsonStructData[i].(map[string]interface{}), resource, "")
+			f.Body().AppendNewline()
+		}
+
+		resourceHandlerFor(resourceType).PostProcess(resourceType, f)
+		if rc.pii != nil {
+			rc.pii.declare(rootBody)
+			if err := rc.pii.writeMapping(rc.piiMappingFile); err != nil {
+				failures = append(failures, err)
+			}
+		}
+		if sensitive != nil {
+			sensitive.declare(rootBody)
+			if err := sensitive.writeSecrets(rc.secretsFile); err != nil {
+				failures = append(failures, err)
+			}
+		}
+		tfOutput := string(hclwrite.Format(f.Bytes()))
+		_, _ = fmt.Fprint(out, tfOutput)
+	}
+	return imports, errors.Join(failures...)
+}
+
+// filterResources returns the resources whose top level attributes equal
+// every value in filters. Values are compared in their string form so that
+// numbers and booleans can be given as strings.
+func filterResources(resources []interface{}, filters map[string]string) []interface{} {
+	kept := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		attrs, ok := resource.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matches := true
+		for attr, want := range filters {
+			if v, ok := attrs[attr]; !ok || v == nil || fmt.Sprint(v) != want {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			kept = append(kept, resource)
+		}
+	}
+	return kept
+}
+
+// fetchV4Resources fetches the resources of resourceType using the
+// cloudflare-go v0 client and reshapes them for v4 of the provider, or for
+// every version in the case of cloudflare_ruleset. The number of resources to
+// generate is returned as well as it can differ from the number fetched.
+func (rc *runContext) fetchV4Resources(ctx context.Context, resourceType string) ([]interface{}, int, error) {
+	resourceCount := 0
+	var jsonStructData []interface{}
+	identifier := rc.scopeIdentifier()
+
+	switch resourceType {
+	case "cloudflare_access_application":
+		jsonPayload, _, err := rc.apiV0.ListAccessApplications(ctx, identifier, cfv0.ListAccessApplicationsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_access_group":
+		jsonPayload, _, err := rc.apiV0.ListAccessGroups(ctx, identifier, cfv0.ListAccessGroupsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_access_identity_provider":
+		jsonPayload, _, err := rc.apiV0.ListAccessIdentityProviders(ctx, identifier, cfv0.ListAccessIdentityProvidersParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_access_service_token":
+		jsonPayload, _, err := rc.apiV0.ListAccessServiceTokens(ctx, identifier, cfv0.ListAccessServiceTokensParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_access_mutual_tls_certificate":
+		jsonPayload, _, err := rc.apiV0.ListAccessMutualTLSCertificates(ctx, identifier, cfv0.ListAccessMutualTLSCertificatesParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_access_rule":
+		if rc.accountID != "" {
+			jsonPayload, err := rc.apiV0.ListAccountAccessRules(ctx, rc.accountID, cfv0.AccessRule{}, 1)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			resourceCount = len(jsonPayload.Result)
+			m, _ := json.Marshal(jsonPayload.Result)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, 0, err
+			}
+		} else {
+			jsonPayload, err := rc.apiV0.ListZoneAccessRules(ctx, rc.zoneID, cfv0.AccessRule{}, 1)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			resourceCount = len(jsonPayload.Result)
+			m, _ := json.Marshal(jsonPayload.Result)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	case "cloudflare_account_member":
+		jsonPayload, _, err := rc.apiV0.AccountMembers(ctx, rc.accountID, cfv0.PaginationOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// remap email and role_ids into the right structure.
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["email_address"] = jsonStructData[i].(map[string]interface{})["user"].(map[string]interface{})["email"]
+			roleIDs := []string{}
+			for _, role := range jsonStructData[i].(map[string]interface{})["roles"].([]interface{}) {
+				roleIDs = append(roleIDs, role.(map[string]interface{})["id"].(string))
+			}
+			jsonStructData[i].(map[string]interface{})["role_ids"] = roleIDs
+		}
+	case "cloudflare_argo":
+		jsonPayload := []cfv0.ArgoFeatureSetting{}
+
+		argoSmartRouting, err := rc.apiV0.ArgoSmartRouting(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+		jsonPayload = append(jsonPayload, argoSmartRouting)
+
+		argoTieredCaching, err := rc.apiV0.ArgoTieredCaching(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+		jsonPayload = append(jsonPayload, argoTieredCaching)
+
+		resourceCount = 1
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i, b := range jsonStructData {
+			key := b.(map[string]interface{})["id"].(string)
+			jsonStructData[0].(map[string]interface{})[key] = jsonStructData[i].(map[string]interface{})["value"]
+		}
+	case "cloudflare_api_shield":
+		jsonPayload := []cfv0.APIShield{}
+		apiShieldConfig, _, err := rc.apiV0.GetAPIShieldConfiguration(ctx, identifier)
+		if err != nil {
+			return nil, 0, err
+		}
+		// the response can contain an empty APIShield struct. Verify we have data before we attempt to do anything
+		jsonPayload = append(jsonPayload, apiShieldConfig)
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// this is only every a 1:1 so we can just verify if the 0th element has they key we expect
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+
+		if jsonStructData[0].(map[string]interface{})["auth_id_characteristics"] == nil {
+			// force a no resources return by setting resourceCount to 0
+			resourceCount = 0
+		}
+	case "cloudflare_user_agent_blocking_rule":
+		page := 1
+		var jsonPayload []cfv0.UserAgentRule
+		for {
+			res, err := rc.apiV0.ListUserAgentRules(ctx, rc.zoneID, page)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			jsonPayload = append(jsonPayload, res.Result...)
+			res.ResultInfo = res.ResultInfo.Next()
+
+			if res.ResultInfo.Done() {
+				break
+			}
+			page = page + 1
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err := json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_bot_management":
+		botManagement, err := rc.apiV0.GetBotManagement(ctx, identifier)
+		if err != nil {
+			return nil, 0, err
+		}
+		var jsonPayload []cfv0.BotManagement
+		jsonPayload = append(jsonPayload, botManagement)
+
+		resourceCount = 1
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+	case "cloudflare_byo_ip_prefix":
+		jsonPayload, err := rc.apiV0.ListPrefixes(ctx, rc.accountID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// remap ID to prefix_id and advertised to advertisement on the JSON payloads.
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["prefix_id"] = jsonStructData[i].(map[string]interface{})["id"]
+
+			if jsonStructData[i].(map[string]interface{})["advertised"].(bool) {
+				jsonStructData[i].(map[string]interface{})["advertisement"] = "on"
+			} else {
+				jsonStructData[i].(map[string]interface{})["advertisement"] = "off"
+			}
+		}
+	case "cloudflare_certificate_pack":
+		jsonPayload, err := rc.apiV0.ListCertificatePacks(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var customerManagedCertificates []cfv0.CertificatePack
+		for _, r := range jsonPayload {
+			if r.Type != "universal" {
+				customerManagedCertificates = append(customerManagedCertificates, r)
+			}
+		}
+		jsonPayload = customerManagedCertificates
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_custom_pages":
+		if rc.accountID != "" {
+			acc := cfv0.CustomPageOptions{AccountID: rc.accountID}
+			jsonPayload, err := rc.apiV0.CustomPages(ctx, &acc)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			resourceCount = len(jsonPayload)
+			m, _ := json.Marshal(jsonPayload)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, 0, err
+			}
+		} else {
+			zo := cfv0.CustomPageOptions{ZoneID: rc.zoneID}
+			jsonPayload, err := rc.apiV0.CustomPages(ctx, &zo)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			resourceCount = len(jsonPayload)
+			m, _ := json.Marshal(jsonPayload)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		var newJsonStructData []interface{}
+		// remap ID to the "type" field
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["type"] = jsonStructData[i].(map[string]interface{})["id"]
+			// we only want repsonses that have 'url'
+			if jsonStructData[i].(map[string]interface{})["url"] != nil {
+				newJsonStructData = append(newJsonStructData, jsonStructData[i])
+			}
+		}
+		jsonStructData = newJsonStructData
+		resourceCount = len(jsonStructData)
+	case "cloudflare_custom_hostname_fallback_origin":
+		var jsonPayload []cfv0.CustomHostnameFallbackOrigin
+		apiCall, err := rc.apiV0.CustomHostnameFallbackOrigin(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if apiCall.Origin != "" {
+			resourceCount = 1
+			jsonPayload = append(jsonPayload, apiCall)
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["id"] = sanitiseTerraformResourceName(jsonStructData[i].(map[string]interface{})["origin"].(string))
+			jsonStructData[i].(map[string]interface{})["status"] = nil
+		}
+	case "cloudflare_filter":
+		jsonPayload, _, err := rc.apiV0.Filters(ctx, identifier, cfv0.FilterListParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_firewall_rule":
+		jsonPayload, _, err := rc.apiV0.FirewallRules(ctx, identifier, cfv0.FirewallRuleListParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// remap Filter.ID to `filter_id` on the JSON payloads.
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["filter_id"] = jsonStructData[i].(map[string]interface{})["filter"].(map[string]interface{})["id"]
+		}
+	case "cloudflare_custom_hostname":
+		jsonPayload, _, err := rc.apiV0.CustomHostnames(ctx, rc.zoneID, 1, cfv0.CustomHostname{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["ssl"].(map[string]interface{})["validation_errors"] = nil
+		}
+	case "cloudflare_custom_ssl":
+		jsonPayload, err := rc.apiV0.ListSSL(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_healthcheck":
+		jsonPayload, err := rc.apiV0.Healthchecks(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_list":
+		jsonPayload, err := rc.apiV0.ListLists(ctx, identifier, cfv0.ListListsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		m, err := json.Marshal(jsonPayload)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if err = json.Unmarshal(m, &jsonStructData); err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+
+		for i := 0; i < resourceCount; i++ {
+			listID := jsonPayload[i].ID
+			kind := jsonPayload[i].Kind
+
+			listItems, err := rc.apiV0.ListListItems(ctx, identifier, cfv0.ListListItemsParams{ID: listID})
+			if err != nil {
+				return nil, 0, err
+			}
+			items := make([]interface{}, 0)
+
+			for _, listItem := range listItems {
+				if kind == "" {
+					continue
+				}
+
+				value := map[string]interface{}{}
+				switch kind {
+				case "ip":
+					if listItem.IP == nil {
+						continue
+					}
+					value["ip"] = *listItem.IP
+				case "asn":
+					if listItem.ASN == nil {
+						continue
+					}
+					value["asn"] = int(*listItem.ASN)
+				case "hostname":
+					if listItem.Hostname == nil {
+						continue
+					}
+					value["hostname"] = map[string]interface{}{
+						"url_hostname": listItem.Hostname.UrlHostname,
+					}
+				case "redirect":
+					if listItem.Redirect == nil {
+						continue
+					}
+					redirect := map[string]interface{}{
+						"source_url": listItem.Redirect.SourceUrl,
+						"target_url": listItem.Redirect.TargetUrl,
+					}
+					if listItem.Redirect.IncludeSubdomains != nil {
+						redirect["include_subdomains"] = boolToEnabledOrDisabled(*listItem.Redirect.IncludeSubdomains)
+					}
+					if listItem.Redirect.SubpathMatching != nil {
+						redirect["subpath_matching"] = boolToEnabledOrDisabled(*listItem.Redirect.SubpathMatching)
+					}
+					if listItem.Redirect.StatusCode != nil {
+						redirect["status_code"] = *listItem.Redirect.StatusCode
+					}
+					if listItem.Redirect.PreserveQueryString != nil {
+						redirect["preserve_query_string"] = boolToEnabledOrDisabled(*listItem.Redirect.PreserveQueryString)
+					}
+					if listItem.Redirect.PreservePathSuffix != nil {
+						redirect["preserve_path_suffix"] = boolToEnabledOrDisabled(*listItem.Redirect.PreservePathSuffix)
+					}
+					value["redirect"] = redirect
+				}
+				items = append(items, map[string]interface{}{
+					"comment": listItem.Comment,
+					"value":   value,
+				})
+			}
+			jsonStructData[i].(map[string]interface{})["item"] = items
+		}
+	case "cloudflare_load_balancer":
+		jsonPayload, err := rc.apiV0.ListLoadBalancers(ctx, identifier, cfv0.ListLoadBalancerParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["default_pool_ids"] = jsonStructData[i].(map[string]interface{})["default_pools"]
+			jsonStructData[i].(map[string]interface{})["fallback_pool_id"] = jsonStructData[i].(map[string]interface{})["fallback_pool"]
+
+			if jsonStructData[i].(map[string]interface{})["country_pools"] != nil {
+				original := jsonStructData[i].(map[string]interface{})["country_pools"]
+				jsonStructData[i].(map[string]interface{})["country_pools"] = []interface{}{}
+
+				for country, popIDs := range original.(map[string]interface{}) {
+					jsonStructData[i].(map[string]interface{})["country_pools"] = append(jsonStructData[i].(map[string]interface{})["country_pools"].([]interface{}), map[string]interface{}{"country": country, "pool_ids": popIDs})
+				}
+			}
+
+			if jsonStructData[i].(map[string]interface{})["region_pools"] != nil {
+				original := jsonStructData[i].(map[string]interface{})["region_pools"]
+				jsonStructData[i].(map[string]interface{})["region_pools"] = []interface{}{}
+
+				for region, popIDs := range original.(map[string]interface{}) {
+					jsonStructData[i].(map[string]interface{})["region_pools"] = append(jsonStructData[i].(map[string]interface{})["region_pools"].([]interface{}), map[string]interface{}{"region": region, "pool_ids": popIDs})
+				}
+			}
+
+			if jsonStructData[i].(map[string]interface{})["pop_pools"] != nil {
+				original := jsonStructData[i].(map[string]interface{})["pop_pools"]
+				jsonStructData[i].(map[string]interface{})["pop_pools"] = []interface{}{}
+
+				for pop, popIDs := range original.(map[string]interface{}) {
+					jsonStructData[i].(map[string]interface{})["pop_pools"] = append(jsonStructData[i].(map[string]interface{})["pop_pools"].([]interface{}), map[string]interface{}{"pop": pop, "pool_ids": popIDs})
+				}
+			}
+		}
+	case "cloudflare_load_balancer_pool":
+		jsonPayload, err := rc.apiV0.ListLoadBalancerPools(ctx, identifier, cfv0.ListLoadBalancerPoolParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			for originCounter := range jsonStructData[i].(map[string]interface{})["origins"].([]interface{}) {
+				if jsonStructData[i].(map[string]interface{})["origins"].([]interface{})[originCounter].(map[string]interface{})["header"] != nil {
+					jsonStructData[i].(map[string]interface{})["origins"].([]interface{})[originCounter].(map[string]interface{})["header"].(map[string]interface{})["header"] = "Host"
+					jsonStructData[i].(map[string]interface{})["origins"].([]interface{})[originCounter].(map[string]interface{})["header"].(map[string]interface{})["values"] = jsonStructData[i].(map[string]interface{})["origins"].([]interface{})[originCounter].(map[string]interface{})["header"].(map[string]interface{})["Host"]
+				}
+			}
+		}
+	case "cloudflare_load_balancer_monitor":
+		jsonPayload, err := rc.apiV0.ListLoadBalancerMonitors(ctx, identifier, cfv0.ListLoadBalancerMonitorParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_logpush_job":
+		jsonPayload, err := rc.apiV0.ListLogpushJobs(ctx, identifier, cfv0.ListLogpushJobsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			// Workaround for LogpushJob.Filter being empty with a custom
+			// marshaler and returning `{"where":{}}` as the "empty" value.
+			if jsonStructData[i].(map[string]interface{})["filter"] == `{"where":{}}` {
+				jsonStructData[i].(map[string]interface{})["filter"] = nil
+			}
+		}
+	case "cloudflare_managed_headers":
+		// only grab the enabled headers
+		jsonPayload, err := rc.apiV0.ListZoneManagedHeaders(ctx, cfv0.ResourceIdentifier(rc.zoneID), cfv0.ListManagedHeadersParams{Status: "enabled"})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var managedHeaders []cfv0.ManagedHeaders
+		managedHeaders = append(managedHeaders, jsonPayload)
+
+		resourceCount = len(managedHeaders)
+		m, _ := json.Marshal(managedHeaders)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["id"] = rc.zoneID
+		}
+	case "cloudflare_origin_ca_certificate":
+		jsonPayload, err := rc.originCAClientV0().ListOriginCACertificates(ctx, cfv0.ListOriginCertificatesParams{ZoneID: rc.zoneID})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_page_rule":
+		jsonPayload, err := rc.apiV0.ListPageRules(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["target"] = jsonStructData[i].(map[string]interface{})["targets"].([]interface{})[0].(map[string]interface{})["constraint"].(map[string]interface{})["value"]
+			jsonStructData[i].(map[string]interface{})["actions"] = rc.flattenAttrMap(jsonStructData[i].(map[string]interface{})["actions"].([]interface{}))
+
+			// Have to remap the cache_ttl_by_status to conform to Terraform's more human-friendly structure.
+			if cache, ok := jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_ttl_by_status"].(map[string]interface{}); ok {
+				cache_ttl_by_status := []map[string]interface{}{}
+
+				for codes, ttl := range cache {
+					if ttl == "no-cache" {
+						ttl = 0
+					} else if ttl == "no-store" {
+						ttl = -1
+					}
+					elem := map[string]interface{}{
+						"codes": codes,
+						"ttl":   ttl,
+					}
+
+					cache_ttl_by_status = append(cache_ttl_by_status, elem)
+				}
+
+				sort.SliceStable(cache_ttl_by_status, func(i int, j int) bool {
+					return cache_ttl_by_status[i]["codes"].(string) < cache_ttl_by_status[j]["codes"].(string)
+				})
+
+				jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_ttl_by_status"] = cache_ttl_by_status
+			}
+
+			// Remap cache_key_fields.query_string.include & .exclude wildcards (not in an array) to the appropriate "ignore" field value in Terraform.
+			if c, ok := jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{}); ok {
+				if s, sok := c["query_string"].(map[string]interface{})["include"].(string); sok && s == "*" {
+					jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["include"] = nil
+					jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["ignore"] = false
+				}
+				if s, sok := c["query_string"].(map[string]interface{})["exclude"].(string); sok && s == "*" {
+					jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["exclude"] = nil
+					jsonStructData[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["ignore"] = true
+				}
+			}
+		}
+	case "cloudflare_rate_limit":
+		jsonPayload, err := rc.apiV0.ListAllRateLimits(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			var bypassItems []string
+
+			// Remap match.request.url to match.request.url_pattern
+			jsonStructData[i].(map[string]interface{})["match"].(map[string]interface{})["request"].(map[string]interface{})["url_pattern"] = jsonStructData[i].(map[string]interface{})["match"].(map[string]interface{})["request"].(map[string]interface{})["url"]
+
+			// Remap bypass to bypass_url_patterns
+			if jsonStructData[i].(map[string]interface{})["bypass"] != nil {
+				for _, item := range jsonStructData[i].(map[string]interface{})["bypass"].([]interface{}) {
+					bypassItems = append(bypassItems, item.(map[string]interface{})["value"].(string))
+				}
+				jsonStructData[i].(map[string]interface{})["bypass_url_patterns"] = bypassItems
+			}
+
+			// Remap match.response.status to match.response.statuses
+			jsonStructData[i].(map[string]interface{})["match"].(map[string]interface{})["response"].(map[string]interface{})["statuses"] = jsonStructData[i].(map[string]interface{})["match"].(map[string]interface{})["response"].(map[string]interface{})["status"]
+		}
+	case "cloudflare_record":
+		jsonPayload, _, err := rc.apiV0.ListDNSRecords(ctx, identifier, cfv0.ListDNSRecordsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		zone, _ := rc.apiV0.ZoneDetails(ctx, identifier.Identifier)
+
+		for i := 0; i < resourceCount; i++ {
+			// Drop the proxiable values as they are not usable
+			jsonStructData[i].(map[string]interface{})["proxiable"] = nil
+			jsonStructData[i].(map[string]interface{})["value"] = nil
+
+			if jsonStructData[i].(map[string]interface{})["name"].(string) != zone.Name {
+				jsonStructData[i].(map[string]interface{})["name"] = strings.ReplaceAll(jsonStructData[i].(map[string]interface{})["name"].(string), "."+zone.Name, "")
+			}
+			if _, hasData := jsonStructData[i].(map[string]interface{})["data"]; hasData {
+				jsonStructData[i].(map[string]interface{})["content"] = nil
+			}
+		}
+	case "cloudflare_ruleset":
+		jsonPayload, err := rc.apiV0.ListRulesets(ctx, identifier, cfv0.ListRulesetsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var nonManagedRules []cfv0.Ruleset
+
+		// A little annoying but makes more sense doing it this way. Only append
+		// the non-managed rules to the usable nonManagedRules variable instead
+		// of attempting to delete from an existing slice and just reassign.
+		for _, r := range jsonPayload {
+			if r.Kind != string(cfv0.RulesetKindManaged) {
+				nonManagedRules = append(nonManagedRules, r)
+			}
+		}
+		jsonPayload = nonManagedRules
+		ruleHeaders := map[string][]map[string]interface{}{}
+		for i, rule := range nonManagedRules {
+			ruleset, _ := rc.apiV0.GetRuleset(ctx, identifier, rule.ID)
+			jsonPayload[i].Rules = ruleset.Rules
+
+			if ruleset.Rules != nil {
+				for _, rule := range ruleset.Rules {
+					if rule.ActionParameters != nil && rule.ActionParameters.Headers != nil {
+						// Sort the headers to have deterministic config output
+						keys := make([]string, 0, len(rule.ActionParameters.Headers))
+						for k := range rule.ActionParameters.Headers {
+							keys = append(keys, k)
+						}
+						sort.Strings(keys)
+
+						// The structure of the API response for headers differs from the
+						// structure terraform requires. So we collect all the headers
+						// indexed by rule.ID to massage the jsonStructData later
+						for _, headerName := range keys {
+							header := map[string]interface{}{
+								"name":       headerName,
+								"operation":  rule.ActionParameters.Headers[headerName].Operation,
+								"expression": rule.ActionParameters.Headers[headerName].Expression,
+								"value":      rule.ActionParameters.Headers[headerName].Value,
+							}
+							ruleHeaders[rule.ID] = append(ruleHeaders[rule.ID], header)
+						}
+					}
+				}
+			}
+		}
+
+		sort.Slice(jsonPayload, func(i, j int) bool {
+			return jsonPayload[i].Phase < jsonPayload[j].Phase
+		})
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if rc.isV5() {
+			for i := 0; i < resourceCount; i++ {
+				rules := jsonStructData[i].(map[string]interface{})["rules"]
+				if rules != nil {
+					for ruleCounter := range rules.([]interface{}) {
+						rules.([]interface{})[ruleCounter].(map[string]interface{})["id"] = nil
+					}
+				}
+			}
+			rc.transformResources(resourceType, &jsonStructData, "")
+			return jsonStructData, resourceCount, nil
+		}
+
+		// Make the rules have the correct header structure
+		for i, ruleset := range jsonStructData {
+			if ruleset.(map[string]interface{})["rules"] != nil {
+				for j, rule := range ruleset.(map[string]interface{})["rules"].([]interface{}) {
+					ID := rule.(map[string]interface{})["id"]
+					if ID != nil {
+						headers, exists := ruleHeaders[ID.(string)]
+						if exists {
+							jsonStructData[i].(map[string]interface{})["rules"].([]interface{})[j].(map[string]interface{})["action_parameters"].(map[string]interface{})["headers"] = headers
+						}
+					}
+				}
+			}
+		}
+
+		// log custom fields specific transformation fields
+		logCustomFieldsTransform := []string{"cookie_fields", "request_fields", "response_fields"}
+
+		for i := 0; i < resourceCount; i++ {
+			rules := jsonStructData[i].(map[string]interface{})["rules"]
+			if rules != nil {
+				for ruleCounter := range rules.([]interface{}) {
+					// should the `ref` be the default `id`, don't output it
+					// as we don't need to track a computed default.
+					id := rules.([]interface{})[ruleCounter].(map[string]interface{})["id"]
+					ref := rules.([]interface{})[ruleCounter].(map[string]interface{})["ref"]
+					if id == ref {
+						rules.([]interface{})[ruleCounter].(map[string]interface{})["ref"] = nil
+					}
+
+					actionParams := rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"]
+					if actionParams != nil {
+						// check for log custom fields that need to be transformed
+						for _, logCustomFields := range logCustomFieldsTransform {
+							// check if the field exists and make sure it has at least one element
+							if actionParams.(map[string]interface{})[logCustomFields] != nil && len(actionParams.(map[string]interface{})[logCustomFields].([]interface{})) > 0 {
+								// Create a new list to store the data in.
+								var newLogCustomFields []interface{}
+								// iterate over each of the keys and add them to a generic list
+								for logCustomFieldsCounter := range actionParams.(map[string]interface{})[logCustomFields].([]interface{}) {
+									newLogCustomFields = append(newLogCustomFields, actionParams.(map[string]interface{})[logCustomFields].([]interface{})[logCustomFieldsCounter].(map[string]interface{})["name"])
+								}
+								actionParams.(map[string]interface{})[logCustomFields] = newLogCustomFields
+							}
+						}
+
+						// check if our ruleset is of action 'skip'
+						if rules.([]interface{})[ruleCounter].(map[string]interface{})["action"] == "skip" {
+							for rule := range actionParams.(map[string]interface{}) {
+								// "rules" is the only map[string][]string we need to remap. The others are all []string and are handled naturally.
+								if rule == "rules" {
+									for key, value := range actionParams.(map[string]interface{})[rule].(map[string]interface{}) {
+										var rulesList []string
+										for _, val := range value.([]interface{}) {
+											rulesList = append(rulesList, val.(string))
+										}
+										actionParams.(map[string]interface{})[rule].(map[string]interface{})[key] = strings.Join(rulesList, ",")
+									}
+								}
+							}
+						}
+
+						// Cache Rules transformation
+						if jsonStructData[i].(map[string]interface{})["phase"] == "http_request_cache_settings" {
+							if ck, ok := rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"]; ok {
+								if c, cok := ck.(map[string]interface{})["custom_key"]; cok {
+									if qs, qok := c.(map[string]interface{})["query_string"]; qok {
+										if s, sok := qs.(map[string]interface{})["include"]; sok && s == "*" {
+											rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"].(map[string]interface{})["custom_key"].(map[string]interface{})["query_string"].(map[string]interface{})["include"] = []interface{}{"*"}
+										}
+										if s, sok := qs.(map[string]interface{})["exclude"]; sok && s == "*" {
+											rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"].(map[string]interface{})["custom_key"].(map[string]interface{})["query_string"].(map[string]interface{})["exclude"] = []interface{}{"*"}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	case "cloudflare_spectrum_application":
+		jsonPayload, err := rc.apiV0.SpectrumApplications(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_teams_list":
+		jsonPayload, _, err := rc.apiV0.ListTeamsLists(ctx, identifier, cfv0.ListTeamListsParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+		// get items for the lists and add it the specific list struct
+		for i, TeamsList := range jsonPayload {
+			items_struct, _, err := rc.apiV0.ListTeamsListItems(
+				ctx,
+				identifier,
+				cfv0.ListTeamsListItemsParams{ListID: TeamsList.ID})
+			if err != nil {
+				return nil, 0, err
+			}
+			TeamsList.Items = append(TeamsList.Items, items_struct...)
+			jsonPayload[i] = TeamsList
+		}
+		m, err := json.Marshal(jsonPayload)
+		if err != nil {
+			return nil, 0, err
+		}
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+
+		// converting the items to value field and not the otherway around
+		for i := 0; i < resourceCount; i++ {
+			if jsonStructData[i].(map[string]interface{})["items"] != nil && len(jsonStructData[i].(map[string]interface{})["items"].([]interface{})) > 0 {
+				// new interface for storing data
+				var newItems []interface{}
+				for _, item := range jsonStructData[i].(map[string]interface{})["items"].([]interface{}) {
+					newItems = append(newItems, item.(map[string]interface{})["value"])
+				}
+				jsonStructData[i].(map[string]interface{})["items"] = newItems
+			}
+		}
+	case "cloudflare_teams_location":
+		jsonPayload, _, err := rc.apiV0.TeamsLocations(ctx, rc.accountID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_teams_proxy_endpoint":
+		jsonPayload, _, err := rc.apiV0.TeamsProxyEndpoints(ctx, rc.accountID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_teams_rule":
+		jsonPayload, err := rc.apiV0.TeamsRules(ctx, rc.accountID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// flatten add_headers of rule setting to a string
+		for i := 0; i < resourceCount; i++ {
+			ruleSettings, ok := jsonStructData[i].(map[string]interface{})["rule_settings"].(map[string]interface{})
+			if ok {
+				addHeaders, ok := ruleSettings["add_headers"].(map[string]interface{})
+				if ok {
+					for k, v := range addHeaders {
+						headerValues := v.([]interface{})
+						headerString := ""
+						for _, headerValue := range headerValues {
+							headerString += strings.Join([]string{headerValue.(string)}, ",")
+						}
+						addHeaders[k] = headerString
+					}
+				}
+			}
+			// check for empty descriptions
+			if jsonStructData[i].(map[string]interface{})["description"] == "" {
+				jsonStructData[i].(map[string]interface{})["description"] = "default"
+			}
+		}
+	case "cloudflare_tunnel":
+		rc.log.Debug("only requesting the first 1000 active Cloudflare Tunnels due to the service not providing correct pagination responses")
+		jsonPayload, _, err := rc.apiV0.ListTunnels(
+			ctx,
+			identifier,
+			cfv0.TunnelListParams{
+				IsDeleted: cfv0.BoolPtr(false),
+				ResultInfo: cfv0.ResultInfo{
+					PerPage: 1000,
+					Page:    1,
+				},
+			})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			secret, err := rc.apiV0.GetTunnelToken(
+				ctx,
+				identifier,
+				jsonStructData[i].(map[string]interface{})["id"].(string),
+			)
+			if err != nil {
+				return nil, 0, err
+			}
+			jsonStructData[i].(map[string]interface{})["secret"] = secret
+			jsonStructData[i].(map[string]interface{})["account_id"] = rc.accountID
+
+			jsonStructData[i].(map[string]interface{})["connections"] = nil
+		}
+	case "cloudflare_turnstile_widget":
+		jsonPayload, _, err := rc.apiV0.ListTurnstileWidgets(ctx, identifier, cfv0.ListTurnstileWidgetParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["id"] = jsonStructData[i].(map[string]interface{})["sitekey"]
+
+			// We always want to emit a list of domains, even if it is empty.
+			// The empty list is used to enable the "Allow on any hostname" feature, it is *not* a default value.
+			if jsonStructData[i].(map[string]interface{})["domains"] == nil {
+				jsonStructData[i].(map[string]interface{})["domains"] = []string{}
+			}
+		}
+	case "cloudflare_url_normalization_settings":
+		jsonPayload, err := rc.apiV0.URLNormalizationSettings(ctx, &cfv0.ResourceContainer{Identifier: rc.zoneID, Level: cfv0.ZoneRouteLevel})
+		if err != nil {
+			return nil, 0, err
+		}
+		var newJsonPayload []interface{}
+		newJsonPayload = append(newJsonPayload, jsonPayload)
+		resourceCount = len(newJsonPayload)
+		m, _ := json.Marshal(newJsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// this is only every a 1:1 so we can just verify if the 0th element has they key we expect
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+	case "cloudflare_waiting_room":
+		jsonPayload, err := rc.apiV0.ListWaitingRooms(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := 0; i < resourceCount; i++ {
+			if jsonStructData[i].(map[string]interface{})["queueing_status_code"].(float64) == 0 {
+				jsonStructData[i].(map[string]interface{})["queueing_status_code"] = nil
+			}
+		}
+	case "cloudflare_waiting_room_event":
+		waitingRooms, err := rc.apiV0.ListWaitingRooms(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := 0; i < len(waitingRooms); i++ {
+			roomEvents, err := rc.apiV0.ListWaitingRoomEvents(ctx, rc.zoneID, waitingRooms[i].ID)
+			if err != nil {
+				return nil, 0, err
+			}
+			m, err := json.Marshal(roomEvents)
+			if err != nil {
+				return nil, 0, err
+			}
+			jsonRoomEvents := []interface{}{}
+			err = json.Unmarshal(m, &jsonRoomEvents)
+			if err != nil {
+				return nil, 0, err
+			}
+			for i := 0; i < len(jsonRoomEvents); i++ {
+				jsonRoomEvents[i].(map[string]interface{})["waiting_room_id"] = waitingRooms[i].ID
+			}
+			jsonStructData = append(jsonStructData, jsonRoomEvents...)
+		}
+		resourceCount = len(jsonStructData)
+	case "cloudflare_waiting_room_rules":
+		waitingRooms, err := rc.apiV0.ListWaitingRooms(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+		roomRules := []struct {
+			ID            string                 `json:"id"`
+			WaitingRoomID string                 `json:"waiting_room_id"`
+			Rules         []cfv0.WaitingRoomRule `json:"rules"`
+		}{}
+		for i := 0; i < len(waitingRooms); i++ {
+			rules, err := rc.apiV0.ListWaitingRoomRules(ctx, cfv0.ZoneIdentifier(rc.zoneID), cfv0.ListWaitingRoomRuleParams{
+				WaitingRoomID: waitingRooms[i].ID,
+			})
+			if err != nil {
+				return nil, 0, err
+			}
+			roomRules = append(roomRules, struct {
+				ID            string                 `json:"id"`
+				WaitingRoomID string                 `json:"waiting_room_id"`
+				Rules         []cfv0.WaitingRoomRule `json:"rules"`
+			}{
+				ID:            waitingRooms[i].ID,
+				WaitingRoomID: waitingRooms[i].ID,
+				Rules:         rules,
+			})
+		}
+		resourceCount = len(roomRules)
+		m, err := json.Marshal(roomRules)
+		if err != nil {
+			return nil, 0, err
+		}
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_waiting_room_settings":
+		waitingRoomSettings, err := rc.apiV0.GetWaitingRoomSettings(ctx, cfv0.ZoneIdentifier(rc.zoneID))
+		if err != nil {
+			return nil, 0, err
+		}
+		var jsonPayload []cfv0.WaitingRoomSettings
+		jsonPayload = append(jsonPayload, waitingRoomSettings)
+
+		resourceCount = 1
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+		jsonStructData[0].(map[string]interface{})["search_engine_crawler_bypass"] = waitingRoomSettings.SearchEngineCrawlerBypass
+	case "cloudflare_workers_kv_namespace":
+		jsonPayload, _, err := rc.apiV0.ListWorkersKVNamespaces(ctx, identifier, cfv0.ListWorkersKVNamespacesParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_worker_route":
+		jsonPayload, err := rc.apiV0.ListWorkerRoutes(ctx, identifier, cfv0.ListWorkerRoutesParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+		resourceCount = len(jsonPayload.Routes)
+		m, _ := json.Marshal(jsonPayload.Routes)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// remap "script_name" to the "script" value.
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["script_name"] = jsonStructData[i].(map[string]interface{})["script"]
+		}
+	case "cloudflare_zone":
+		jsonPayload, err := rc.apiV0.ListZones(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// - remap "zone" to the "name" value
+		// - remap "plan" to "legacy_id" value
+		// - drop meta and name_servers
+		// - pull in the account_id field
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["zone"] = jsonStructData[i].(map[string]interface{})["name"]
+			jsonStructData[i].(map[string]interface{})["plan"] = jsonStructData[i].(map[string]interface{})["plan"].(map[string]interface{})["legacy_id"].(string)
+			jsonStructData[i].(map[string]interface{})["meta"] = nil
+			jsonStructData[i].(map[string]interface{})["name_servers"] = nil
+			jsonStructData[i].(map[string]interface{})["status"] = nil
+			jsonStructData[i].(map[string]interface{})["account_id"] = jsonStructData[i].(map[string]interface{})["account"].(map[string]interface{})["id"].(string)
+		}
+	case "cloudflare_zone_lockdown":
+		jsonPayload, _, err := rc.apiV0.ListZoneLockdowns(ctx, identifier, cfv0.LockdownListParams{})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = len(jsonPayload)
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+	case "cloudflare_zone_settings_override":
+		jsonPayload, err := rc.apiV0.ZoneSettings(ctx, rc.zoneID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resourceCount = 1
+		m, _ := json.Marshal(jsonPayload.Result)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		zoneSettingsStruct := make(map[string]interface{})
+		for _, data := range jsonStructData {
+			keyName := data.(map[string]interface{})["id"].(string)
+			value := data.(map[string]interface{})["value"]
+			zoneSettingsStruct[keyName] = value
+		}
+
+		// Remap all settings under "settings" block as well as some of the
+		// attributes that are not 1:1 with the API.
+		for i := 0; i < resourceCount; i++ {
+			jsonStructData[i].(map[string]interface{})["id"] = rc.zoneID
+			jsonStructData[i].(map[string]interface{})["settings"] = zoneSettingsStruct
+
+			// zero RTT
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["zero_rtt"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["0rtt"]
+
+			// Mobile subdomain redirects
+			if jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["mobile_redirect"].(map[string]interface{})["status"] == "off" {
+				jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["mobile_redirect"] = nil
+			}
+
+			// HSTS
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["enabled"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["strict_transport_security"].(map[string]interface{})["enabled"]
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["include_subdomains"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["strict_transport_security"].(map[string]interface{})["include_subdomains"]
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["max_age"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["strict_transport_security"].(map[string]interface{})["max_age"]
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["preload"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["strict_transport_security"].(map[string]interface{})["preload"]
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["nosniff"] = jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["security_header"].(map[string]interface{})["strict_transport_security"].(map[string]interface{})["nosniff"]
+
+			// tls_1_2_only is deprecated in favour of min_tls
+			jsonStructData[i].(map[string]interface{})["settings"].(map[string]interface{})["tls_1_2_only"] = nil
+		}
+	case "cloudflare_tiered_cache":
+		tieredCache, err := rc.apiV0.GetTieredCache(ctx, &cfv0.ResourceContainer{Identifier: rc.zoneID})
+		if err != nil {
+			return nil, 0, err
+		}
+		var jsonPayload []cfv0.TieredCache
+		jsonPayload = append(jsonPayload, tieredCache)
+
+		resourceCount = 1
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+		jsonStructData[0].(map[string]interface{})["cache_type"] = tieredCache.Type.String()
+	default:
+		return nil, 0, errNotSupported
+	}
+	return jsonStructData, resourceCount, nil
+}
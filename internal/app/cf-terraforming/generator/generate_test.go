@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// listOfString is an example representation of a key where the value is a
+	// list of string values.
+	//
+	//   resource "example" "example" {
+	//     attr = [ "b", "c", "d"]
+	//   }
+	listOfString = []interface{}{"b", "c", "d"}
+
+	// configBlockOfStrings is an example of where a key is a "block" assignment
+	// in HCL.
+	//
+	//   resource "example" "example" {
+	//     attr = {
+	//       c = "d"
+	//       e = "f"
+	//     }
+	//   }
+	configBlockOfStrings = map[string]interface{}{
+		"c": "d",
+		"e": "f",
+	}
+
+	cloudflareTestZoneID    = "0da42c8d2132a9ddaf714f9e7c920711"
+	cloudflareTestAccountID = "f037e56e89293a057740de681ac9abbe"
+)
+
+func TestGenerate_writeAttrLineV4(t *testing.T) {
+	rc := &runContext{log: logrus.New()}
+	multilineListOfStrings := heredoc.Doc(`
+		a = ["b", "c", "d"]
+	`)
+	multilineBlock := heredoc.Doc(`
+		a = {
+		  c = "d"
+		  e = "f"
+		}
+	`)
+	tests := map[string]struct {
+		key   string
+		value interface{}
+		want  string
+	}{
+		"value is string":           {key: "a", value: "b", want: fmt.Sprintf("a = %q\n", "b")},
+		"value is int":              {key: "a", value: 1, want: "a = 1\n"},
+		"value is float":            {key: "a", value: 1.0, want: "a = 1\n"},
+		"value is bool":             {key: "a", value: true, want: "a = true\n"},
+		"value is list of strings":  {key: "a", value: listOfString, want: multilineListOfStrings},
+		"value is block of strings": {key: "a", value: configBlockOfStrings, want: multilineBlock},
+		"value is nil":              {key: "a", value: nil, want: ""},
+	}
+
+	for name, tc := range tests {
+		f := hclwrite.NewEmptyFile()
+		t.Run(name, func(t *testing.T) {
+			rc.writeAttrLine(tc.key, tc.value, "", f.Body())
+			assert.Equal(t, tc.want, string(f.Bytes()))
+		})
+	}
+}
@@ -0,0 +1,294 @@
+// Package generator fetches Cloudflare resources and renders them as
+// Terraform configuration, import blocks or `terraform import` commands. It is
+// configured entirely through Options and is shared by the cf-terraforming
+// commands and the terraforming library.
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Options configure a Generator.
+type Options struct {
+	// APIToken authenticates the requests to the Cloudflare API. Either it
+	// or both APIKey and APIEmail must be set.
+	APIToken string
+	APIKey   string
+	APIEmail string
+
+	// OriginCAKey is used for the Origin CA certificate endpoints. It is
+	// enough on its own to fetch cloudflare_origin_ca_certificate.
+	OriginCAKey string
+
+	// AccountID or ZoneID is the scope resources are fetched for. At most
+	// one of them can be set.
+	AccountID string
+	ZoneID    string
+
+	// ResourceIDs are the parent IDs, keyed by resource type, of resource
+	// types that are fetched per parent, such as the settings of
+	// cloudflare_zone_setting.
+	ResourceIDs map[string][]string
+
+	// Filters are the values of top level attributes, keyed by resource
+	// type and attribute, that resources must have to be included. Values
+	// are compared as strings.
+	Filters map[string]map[string]string
+
+	// ProviderVersion is the version of the Cloudflare provider the output
+	// is for.
+	ProviderVersion string
+
+	// HTTPClient sends the API requests. http.DefaultClient is used when it
+	// is nil.
+	HTTPClient *http.Client
+
+	// BaseURL replaces the public Cloudflare API, e.g. for a mock server or
+	// a proxy.
+	BaseURL string
+
+	// API and APIV0 are used instead of creating clients from the options
+	// above when they are set.
+	API   *cloudflare.Client
+	APIV0 *cfv0.API
+
+	// CacheDir enables the encrypted response cache. Entries older than
+	// CacheTTL are ignored, as are all of them when Refresh is set.
+	CacheDir string
+	CacheTTL time.Duration
+	Refresh  bool
+
+	// SnapshotDir is the directory API responses are written to while a
+	// snapshot is taken. FromSnapshotDir is the directory they are read from
+	// instead of calling the API.
+	SnapshotDir     string
+	FromSnapshotDir string
+
+	// Transforms is a file of transform rules that override the embedded
+	// ones per resource type.
+	Transforms string
+
+	// Transformers are the executables, keyed by resource type, that
+	// rewrite the resources of a type. Each call is stopped after
+	// TransformerTimeout, which defaults to DefaultTransformerTimeout.
+	Transformers       map[string]string
+	TransformerTimeout time.Duration
+
+	// StubDir is the directory placeholder files are created in for values
+	// the API doesn't return, such as private keys. It defaults to the
+	// working directory.
+	StubDir string
+
+	// SensitiveVariables replaces sensitive attributes with variables whose
+	// values are written to SecretsFile.
+	SensitiveVariables bool
+	SecretsFile        string
+
+	// RedactPII is the mode, PIIModeHash or PIIModeVariable, personally
+	// identifiable information is replaced with. The original values are
+	// written to PIIMappingFile.
+	RedactPII      string
+	PIIMappingFile string
+
+	// Logger receives the diagnostics of the Generator, such as skipped
+	// resource types and API errors. The standard logger of logrus is used
+	// when it is nil.
+	Logger *logrus.Logger
+}
+
+// Generator fetches and renders the resources of a single scope.
+type Generator struct {
+	rc *runContext
+}
+
+// New returns a Generator for opts. API clients are only created when the
+// resources aren't read from a snapshot and none are given in opts.
+func New(opts Options) (*Generator, error) {
+	if opts.AccountID != "" && opts.ZoneID != "" {
+		return nil, errors.New("--account and --zone are mutually exclusive, support for both is deprecated")
+	}
+
+	rc := &runContext{
+		accountID: opts.AccountID,
+		zoneID:    opts.ZoneID,
+		credentials: credentials{
+			apiToken:    opts.APIToken,
+			apiKey:      opts.APIKey,
+			apiEmail:    opts.APIEmail,
+			originCAKey: opts.OriginCAKey,
+		},
+		api:                opts.API,
+		apiV0:              opts.APIV0,
+		providerVersion:    opts.ProviderVersion,
+		resourceIDs:        opts.ResourceIDs,
+		filters:            opts.Filters,
+		fromSnapshotDir:    opts.FromSnapshotDir,
+		snapshotDir:        opts.SnapshotDir,
+		transformers:       opts.Transformers,
+		transformerTimeout: opts.TransformerTimeout,
+		stubDir:            opts.StubDir,
+		sensitiveVariables: opts.SensitiveVariables,
+		secretsFile:        opts.SecretsFile,
+		piiMappingFile:     opts.PIIMappingFile,
+		log:                opts.Logger,
+	}
+	if rc.log == nil {
+		rc.log = logrus.StandardLogger()
+	}
+	if rc.transformerTimeout == 0 {
+		rc.transformerTimeout = DefaultTransformerTimeout
+	}
+	if rc.stubDir == "" {
+		rc.stubDir = "."
+	}
+
+	var err error
+	if rc.transformRules, err = loadTransformRules(opts.Transforms, rc.log); err != nil {
+		return nil, err
+	}
+	if opts.RedactPII != "" {
+		if rc.pii, err = newPIIRedactor(opts.RedactPII, opts.PIIMappingFile, rc.log); err != nil {
+			return nil, err
+		}
+	}
+	if rc.fromSnapshotDir != "" {
+		return &Generator{rc: rc}, nil
+	}
+
+	baseURL := strings.TrimSuffix(opts.BaseURL, "/")
+	if opts.CacheDir != "" {
+		rc.cache, err = newAPICache(opts.CacheDir, opts.CacheTTL, opts.Refresh, baseURL, rc.credentials.cacheKey(), rc.log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rc.api == nil && rc.apiV0 == nil {
+		httpClient := http.DefaultClient
+		if opts.HTTPClient != nil {
+			httpClient = opts.HTTPClient
+		}
+		if rc.apiV0, rc.api, err = newAPIClients(httpClient, baseURL, rc.credentials, rc.log.IsLevelEnabled(logrus.DebugLevel)); err != nil {
+			return nil, fmt.Errorf("failed to create API clients: %w", err)
+		}
+	}
+	return &Generator{rc: rc}, nil
+}
+
+// Generate writes the configuration for every resource type in resources to
+// out using the provider schema s. Notices, such as resource types without
+// any resources, are written to errOut. Resource types that can't be fetched
+// don't stop the others from being generated; their errors are joined in the
+// returned error. The import blocks for the generated resources are returned
+// as well.
+func (g *Generator) Generate(ctx context.Context, out, errOut io.Writer, s *tfjson.ProviderSchema, resources []string) ([]ImportBlock, error) {
+	return g.rc.generateResourceTypes(ctx, out, errOut, s, resources)
+}
+
+// Import writes the `terraform import` commands, or the import blocks when
+// modern is set, for every resource of the resource types in resources to
+// out.
+func (g *Generator) Import(ctx context.Context, out, errOut io.Writer, resources []string, modern bool) error {
+	return g.rc.importResources(ctx, out, errOut, resources, modern)
+}
+
+// Snapshot fetches every resource type in resources so that the responses
+// are written to the snapshot directory and returns the resource types that
+// were captured.
+func (g *Generator) Snapshot(ctx context.Context, resources []string) ([]string, error) {
+	rc := g.rc
+	captured := make([]string, 0, len(resources))
+	var failures []error
+	for _, resourceType := range resources {
+		// The ruleset resource is built using the cloudflare-go v0 SDK which
+		// doesn't go through the generic endpoint fetching.
+		if resourceType == "cloudflare_ruleset" || rc.resourceEndpoint(resourceType) == "" {
+			rc.log.WithFields(logrus.Fields{
+				"resource": resourceType,
+			}).Warn("resource is not supported by snapshots")
+			continue
+		}
+
+		ids, err := rc.parentIDs(resources, resourceType)
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		jsonStructData, err := rc.fetchResources(ctx, resourceType, ids)
+		if err != nil {
+			rc.log.Infof("error getting API response for resource %s: %s", resourceType, err)
+			if !isEmpty(err) {
+				failures = append(failures, fmt.Errorf("%s: %w", resourceType, err))
+			}
+			continue
+		}
+
+		rc.log.WithFields(logrus.Fields{
+			"count":    len(jsonStructData),
+			"resource": resourceType,
+		}).Debug("captured resource snapshot")
+		captured = append(captured, resourceType)
+	}
+	return captured, errors.Join(failures...)
+}
+
+// VerifyToken returns the status of the API token. API keys can't be
+// verified and are reported as such.
+func (g *Generator) VerifyToken(ctx context.Context) (string, error) {
+	return g.rc.verifyToken(ctx)
+}
+
+// Preflight checks which resource types in resources the credentials can
+// read, writes the outcome as a table to w and returns the number of resource
+// types that were denied.
+func (g *Generator) Preflight(ctx context.Context, w io.Writer, resources []string) int {
+	results := g.rc.preflightResources(ctx, resources)
+	writePreflightTable(w, results)
+
+	denied := 0
+	for _, r := range results {
+		if r.Status == preflightDenied {
+			denied++
+		}
+	}
+	return denied
+}
+
+// LogStubFiles lists the files created so far that must be filled in before
+// the generated configuration can be applied.
+func (g *Generator) LogStubFiles() {
+	g.rc.logStubFiles()
+}
+
+// ImportBlock is a Terraform `import` block for a generated resource.
+type ImportBlock struct {
+	// To is the address of the resource, such as
+	// cloudflare_dns_record.terraform_managed_resource_0.
+	To string
+
+	// ID is the import ID of the resource.
+	ID string
+}
+
+// WriteImportBlocks appends an `import` block to body for each of imports.
+func WriteImportBlocks(body *hclwrite.Body, imports []ImportBlock) {
+	for _, i := range imports {
+		block := body.AppendNewBlock("import", []string{}).Body()
+		block.SetAttributeRaw("to", hclwrite.TokensForIdentifier(i.To))
+		block.SetAttributeValue("id", cty.StringVal(i.ID))
+		body.AppendNewline()
+	}
+}
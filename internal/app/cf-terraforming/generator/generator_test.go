@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// dnsRecordSchema is a minimal provider schema for cloudflare_dns_record.
+var dnsRecordSchema = &tfjson.ProviderSchema{
+	ResourceSchemas: map[string]*tfjson.Schema{
+		"cloudflare_dns_record": {Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"id":      {AttributeType: cty.String, Computed: true},
+				"zone_id": {AttributeType: cty.String, Required: true},
+				"name":    {AttributeType: cty.String, Required: true},
+				"type":    {AttributeType: cty.String, Required: true},
+				"content": {AttributeType: cty.String, Optional: true},
+			},
+		}},
+	},
+}
+
+func TestGenerator(t *testing.T) {
+	t.Setenv("USE_STATIC_RESOURCE_IDS", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/client/v4/zones/" + cloudflareTestZoneID + "/dns_records":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[{"id":"abc","name":"example.com","type":"A","content":"192.0.2.1"}]}`))
+		case "/client/v4/zones/" + cloudflareTestZoneID + "/dnssec":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":{"status":"active"}}`))
+		case "/client/v4/zones/" + cloudflareTestZoneID + "/email/routing/rules":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":1000,"message":"not found"}],"messages":[],"result":null}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+		}
+	}))
+	defer server.Close()
+
+	g, err := New(Options{
+		APIToken:        "test-token",
+		ZoneID:          cloudflareTestZoneID,
+		ProviderVersion: "5.0.0",
+		HTTPClient:      server.Client(),
+		BaseURL:         server.URL + "/client/v4/",
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	imports, err := g.Generate(context.Background(), &out, &out, dnsRecordSchema, []string{"cloudflare_dns_record"})
+	require.NoError(t, err)
+	assert.Equal(t, `resource "cloudflare_dns_record" "terraform_managed_resource_abc_0" {
+  content = "192.0.2.1"
+  name    = "example.com"
+  type    = "A"
+  zone_id = "`+cloudflareTestZoneID+`"
+}
+
+`, out.String())
+	assert.Equal(t, []ImportBlock{
+		{To: "cloudflare_dns_record.terraform_managed_resource_abc_0", ID: cloudflareTestZoneID + "/abc"},
+	}, imports)
+
+	t.Run("failing resource types are returned", func(t *testing.T) {
+		out.Reset()
+		imports, err := g.Generate(context.Background(), &out, &out, dnsRecordSchema, []string{"cloudflare_page_rule", "cloudflare_dns_record"})
+		assert.ErrorContains(t, err, "cloudflare_page_rule")
+		assert.Contains(t, out.String(), `resource "cloudflare_dns_record"`)
+		assert.Len(t, imports, 1)
+	})
+
+	t.Run("resource types without resources aren't failures", func(t *testing.T) {
+		out.Reset()
+		_, err := g.Generate(context.Background(), &out, &out, dnsRecordSchema, []string{"cloudflare_email_routing_rule"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("resource types filtered to nothing don't stop the others", func(t *testing.T) {
+		g, err := New(Options{
+			APIToken:        "test-token",
+			ZoneID:          cloudflareTestZoneID,
+			Filters:         map[string]map[string]string{"cloudflare_zone_dnssec": {"status": "disabled"}},
+			ProviderVersion: "5.0.0",
+			HTTPClient:      server.Client(),
+			BaseURL:         server.URL + "/client/v4/",
+		})
+		require.NoError(t, err)
+		s := &tfjson.ProviderSchema{ResourceSchemas: map[string]*tfjson.Schema{
+			"cloudflare_dns_record": dnsRecordSchema.ResourceSchemas["cloudflare_dns_record"],
+			"cloudflare_zone_dnssec": {Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"zone_id": {AttributeType: cty.String, Required: true},
+					"status":  {AttributeType: cty.String, Optional: true},
+				},
+			}},
+		}}
+
+		var out, notices bytes.Buffer
+		imports, err := g.Generate(context.Background(), &out, &notices, s, []string{"cloudflare_zone_dnssec", "cloudflare_dns_record"})
+		require.NoError(t, err)
+		assert.Equal(t, "no resources of type \"cloudflare_zone_dnssec\" found to generate\n", notices.String())
+		assert.NotContains(t, out.String(), "cloudflare_zone_dnssec")
+		assert.Contains(t, out.String(), `resource "cloudflare_dns_record"`)
+		assert.Len(t, imports, 1)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		out.Reset()
+		require.NoError(t, g.Import(context.Background(), &out, &out, []string{"cloudflare_dns_record"}, true))
+		assert.Contains(t, out.String(), `to = cloudflare_dns_record.terraform_managed_resource_abc_0`)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := New(Options{AccountID: cloudflareTestAccountID, ZoneID: cloudflareTestZoneID})
+		assert.ErrorContains(t, err, "mutually exclusive")
+		_, err = New(Options{ZoneID: cloudflareTestZoneID, RedactPII: "nope"})
+		assert.Error(t, err)
+	})
+}
+
+func TestGeneratorsAreIndependent(t *testing.T) {
+	const deniedZoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/client/v4/zones/"+cloudflareTestZoneID+"/dns_records" {
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[{"id":"abc","name":"example.com","type":"A","content":"192.0.2.1"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// each Generator logs to its own logger, so the diagnostics of one never
+	// end up in the other's even when they run at the same time.
+	for name, tc := range map[string]struct {
+		zoneID string
+		level  logrus.Level
+		want   string
+		denied bool
+	}{
+		"allowed": {zoneID: cloudflareTestZoneID, level: logrus.DebugLevel, want: `resource "cloudflare_dns_record"`},
+		"denied":  {zoneID: deniedZoneID, level: logrus.InfoLevel, denied: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var logs bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&logs)
+			logger.SetLevel(tc.level)
+
+			g, err := New(Options{
+				APIToken:        "test-token",
+				ZoneID:          tc.zoneID,
+				ProviderVersion: "5.0.0",
+				HTTPClient:      server.Client(),
+				BaseURL:         server.URL + "/client/v4",
+				Logger:          logger,
+			})
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			for range 5 {
+				out.Reset()
+				_, err = g.Generate(context.Background(), &out, &out, dnsRecordSchema, []string{"cloudflare_dns_record"})
+			}
+			if tc.denied {
+				assert.ErrorContains(t, err, "cloudflare_dns_record")
+				assert.Contains(t, logs.String(), "error getting API response for resource cloudflare_dns_record")
+				assert.NotContains(t, logs.String(), cloudflareTestZoneID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tc.want)
+			assert.Contains(t, out.String(), cloudflareTestZoneID)
+			assert.NotContains(t, logs.String(), "error getting API response")
+			assert.NotContains(t, logs.String(), deniedZoneID)
+		})
+	}
+}
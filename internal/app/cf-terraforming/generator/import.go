@@ -0,0 +1,759 @@
+package generator
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const (
+	terraformImportCmdPrefix    = "terraform import"
+	terraformResourceNamePrefix = "terraform_managed_resource"
+)
+
+// resourceImportStringFormats contains a mapping of the resource type to the
+// composite ID that is compatible with performing an import with v4 of the
+// provider. Unlike the import IDs of v5 in resource_to_endpoint_mapping.go it
+// isn't generated, as v4 isn't described by the Stainless configuration, and is
+// maintained by hand for the resource types v4 supports.
+var resourceImportStringFormats = map[string]string{
+	"cloudflare_access_application":                            ":account_id/:id",
+	"cloudflare_access_group":                                  ":account_id/:id",
+	"cloudflare_access_rule":                                   ":identifier_type/:identifier_value/:id",
+	"cloudflare_account":                                       ":account_id",
+	"cloudflare_account_member":                                ":account_id/:id",
+	"cloudflare_api_shield_operation":                          ":zone_id/:id",
+	"cloudflare_argo":                                          ":zone_id/argo",
+	"cloudflare_bot_management":                                ":zone_id",
+	"cloudflare_byo_ip_prefix":                                 ":id",
+	"cloudflare_certificate_pack":                              ":zone_id/:id",
+	"cloudflare_custom_hostname":                               ":zone_id/:id",
+	"cloudflare_custom_pages":                                  ":identifier_type/:identifier_value/:id",
+	"cloudflare_custom_ssl":                                    ":zone_id/:id",
+	"cloudflare_d1_database":                                   ":account_id/:id",
+	"cloudflare_dns_firewall":                                  ":account_id/:id",
+	"cloudflare_dns_record":                                    ":zone_id/:id",
+	"cloudflare_dns_zone_transfers_acl":                        ":account_id/:id",
+	"cloudflare_dns_zone_transfers_incoming":                   ":zone_id",
+	"cloudflare_dns_zone_transfers_outgoing":                   ":zone_id",
+	"cloudflare_dns_zone_transfers_peer":                       ":account_id/:id",
+	"cloudflare_dns_zone_transfers_tsig":                       ":account_id/:id",
+	"cloudflare_email_routing_address":                         ":account_id/:id",
+	"cloudflare_email_routing_catch_all":                       ":zone_id",
+	"cloudflare_email_routing_dns":                             ":zone_id",
+	"cloudflare_email_routing_rule":                            ":zone_id/:id",
+	"cloudflare_email_routing_settings":                        ":zone_id",
+	"cloudflare_email_security_block_sender":                   ":account_id/:id",
+	"cloudflare_email_security_impersonation_registry":         ":account_id/:id",
+	"cloudflare_email_security_trusted_domains":                ":account_id/:id",
+	"cloudflare_filter":                                        ":zone_id/:id",
+	"cloudflare_firewall_rule":                                 ":zone_id/:id",
+	"cloudflare_healthcheck":                                   ":zone_id/:id",
+	"cloudflare_ip_list":                                       ":account_id/:id",
+	"cloudflare_keyless_certificate":                           ":zone_id/:id",
+	"cloudflare_list":                                          ":account_id/:id",
+	"cloudflare_load_balancer":                                 ":zone_id/:id",
+	"cloudflare_load_balancer_monitor":                         ":account_id/:id",
+	"cloudflare_load_balancer_pool":                            ":account_id/:id",
+	"cloudflare_managed_transforms":                            ":zone_id",
+	"cloudflare_mtls_certificate":                              ":account_id/:id",
+	"cloudflare_notification_policy":                           ":account_id/:id",
+	"cloudflare_notification_policy_webhooks":                  ":account_id/:id",
+	"cloudflare_origin_ca_certificate":                         ":id",
+	"cloudflare_page_rule":                                     ":zone_id/:id",
+	"cloudflare_page_shield_policy":                            ":zone_id/:id",
+	"cloudflare_pages_project":                                 ":account_id/:id",
+	"cloudflare_queue":                                         ":account_id/:id",
+	"cloudflare_r2_bucket":                                     ":account_id/:id",
+	"cloudflare_rate_limit":                                    ":zone_id/:id",
+	"cloudflare_record":                                        ":zone_id/:id",
+	"cloudflare_regional_hostname":                             ":zone_id/:id",
+	"cloudflare_regional_tiered_cache":                         ":zone_id",
+	"cloudflare_ruleset":                                       ":identifier_type/:identifier_value/:id",
+	"cloudflare_spectrum_application":                          ":zone_id/:id",
+	"cloudflare_stream_key":                                    ":account_id",
+	"cloudflare_tiered_cache":                                  ":zone_id",
+	"cloudflare_total_tls":                                     ":zone_id",
+	"cloudflare_tunnel":                                        ":account_id/:id",
+	"cloudflare_turnstile_widget":                              ":account_id/:id",
+	"cloudflare_url_normalization_settings":                    ":zone_id",
+	"cloudflare_waf_override":                                  ":zone_id/:id",
+	"cloudflare_waiting_room":                                  ":zone_id/:id",
+	"cloudflare_waiting_room_settings":                         ":zone_id",
+	"cloudflare_web3_hostname":                                 ":zone_id/:id",
+	"cloudflare_web_analytics_site":                            ":account_id/:id",
+	"cloudflare_worker_route":                                  ":zone_id/:id",
+	"cloudflare_workers_custom_domain":                         ":account_id/:id",
+	"cloudflare_workers_for_platforms_dispatch_namespace":      ":account_id/:id",
+	"cloudflare_workers_kv_namespace":                          ":account_id/:id",
+	"cloudflare_zone":                                          ":id",
+	"cloudflare_zone_dnssec":                                   ":zone_id",
+	"cloudflare_zone_lockdown":                                 ":zone_id/:id",
+	"cloudflare_zone_setting":                                  ":zone_id/:id",
+	"cloudflare_zero_trust_access_application":                 ":identifier_type/:identifier_value/:id",
+	"cloudflare_zero_trust_access_custom_page":                 ":account_id/:id",
+	"cloudflare_zero_trust_access_infrastructure_target":       ":account_id/:id",
+	"cloudflare_zero_trust_access_key_configuration":           ":account_id",
+	"cloudflare_zero_trust_access_policy":                      ":account_id/:id",
+	"cloudflare_zero_trust_access_short_lived_certificate":     ":identifier_type/:identifier_value/:id",
+	"cloudflare_zero_trust_access_tag":                         ":account_id/:id",
+	"cloudflare_zero_trust_device_custom_profile":              ":account_id/:id",
+	"cloudflare_zero_trust_device_default_profile":             ":account_id",
+	"cloudflare_zero_trust_device_managed_networks":            ":account_id/:id",
+	"cloudflare_zero_trust_device_posture_integration":         ":account_id/:id",
+	"cloudflare_zero_trust_device_posture_rule":                ":account_id/:id",
+	"cloudflare_zero_trust_dex_test":                           ":account_id/:id",
+	"cloudflare_zero_trust_dlp_predefined_profile":             ":account_id/:id",
+	"cloudflare_zero_trust_dns_location":                       ":account_id/:id",
+	"cloudflare_zero_trust_gateway_certificate":                ":account_id/:id",
+	"cloudflare_zero_trust_gateway_policy":                     ":account_id/:id",
+	"cloudflare_zero_trust_gateway_proxy_endpoint":             ":account_id/:id",
+	"cloudflare_zero_trust_gateway_settings":                   ":account_id",
+	"cloudflare_zero_trust_list":                               ":account_id/:id",
+	"cloudflare_zero_trust_risk_scoring_integration":           ":account_id/:id",
+	"cloudflare_zero_trust_tunnel_cloudflared":                 ":account_id/:id",
+	"cloudflare_zero_trust_tunnel_cloudflared_route":           ":account_id/:id",
+	"cloudflare_zero_trust_tunnel_cloudflared_virtual_network": ":account_id/:id",
+}
+
+// importResources writes the `terraform import` commands, or the import
+// blocks when modern is set, for every resource of the resource types in
+// resources to out.
+func (rc *runContext) importResources(ctx context.Context, out, errOut io.Writer, resources []string, modern bool) error {
+	if !rc.isV5() && rc.fromSnapshotDir != "" {
+		return errors.New("--from-snapshot is only supported with v5 of the provider")
+	}
+
+	var failures []error
+	var imports []ImportBlock
+	for _, resourceType := range resources {
+		var jsonStructData []interface{}
+		if rc.isV5() {
+			ids, err := rc.parentIDs(resources, resourceType)
+			if err != nil {
+				failures = append(failures, err)
+				continue
+			}
+			jsonStructData, err = rc.fetchResources(ctx, resourceType, ids)
+			if err != nil {
+				rc.log.Infof("error getting API response for resource %s: %s", resourceType, err)
+				if !isEmpty(err) {
+					failures = append(failures, fmt.Errorf("%s: %w", resourceType, err))
+				}
+				continue
+			}
+
+			jsonStructData, err = rc.applyTransformer(ctx, resourceType, jsonStructData)
+			if err != nil {
+				failures = append(failures, err)
+				continue
+			}
+			if filters := rc.filters[resourceType]; len(filters) > 0 {
+				jsonStructData = filterResources(jsonStructData, filters)
+			}
+		} else {
+			var err error
+			jsonStructData, err = rc.fetchV4ImportResources(ctx, resourceType)
+			if errors.Is(err, errNotSupported) {
+				fmt.Fprintf(errOut, "%q is not yet supported for state import\n", resourceType)
+				continue
+			}
+			if err != nil {
+				failures = append(failures, fmt.Errorf("%s: %w", resourceType, err))
+				continue
+			}
+			if _, ok := resourceImportStringFormats[resourceType]; !ok {
+				failures = append(failures, fmt.Errorf("%s does not have an import format defined", resourceType))
+				continue
+			}
+		}
+
+		for i, data := range jsonStructData {
+			id := rc.importResourceID(resourceType, data.(map[string]interface{}))
+			if modern {
+				imports = append(imports, ImportBlock{
+					To: fmt.Sprintf("%s.%s", resourceType, fmt.Sprintf("%s_%s_%d", terraformResourceNamePrefix, id, i)),
+					ID: rc.buildRawImportAddress(resourceType, id, resourceToEndpoint[resourceType].importID),
+				})
+			} else {
+				_, _ = fmt.Fprint(out, rc.buildTerraformImportCommand(i, resourceType, id, resourceToEndpoint[resourceType].importID))
+			}
+		}
+	}
+
+	if modern {
+		// don't format the output; there is a bug in hclwrite.Format that
+		// splits incorrectly on certain characters. instead, manually
+		// insert new lines on the block.
+		importFile := hclwrite.NewEmptyFile()
+		WriteImportBlocks(importFile.Body(), imports)
+		_, _ = fmt.Fprint(out, string(importFile.Bytes()))
+	}
+	return errors.Join(failures...)
+}
+
+// buildTerraformImportCommand takes the resourceType and resourceID in order to
+// look up the resource type import string and then return a suitable composite
+// value that is compatible with `terraform import`.
+//
+// Note: `importID` is only used on > v4. Otherwise, it is ignored.
+func (rc *runContext) buildTerraformImportCommand(i int, resourceType, resourceID, importID string) string {
+	resourceImportAddress := rc.buildRawImportAddress(resourceType, resourceID, importID)
+	return fmt.Sprintf("%s %s.%s %s\n", terraformImportCmdPrefix, resourceType, fmt.Sprintf("%s_%s_%d", terraformResourceNamePrefix, resourceID, i), resourceImportAddress)
+}
+
+// importResourceID returns the ID of the resource of resourceType in data.
+// Resources without an ID of their own, such as zone settings, use the ID of
+// the zone or account.
+func (rc *runContext) importResourceID(resourceType string, data map[string]interface{}) string {
+	field := resourceToEndpoint[resourceType].idField
+	if field == "" {
+		field = "id"
+	}
+
+	var id string
+	if data[field] == nil {
+		if rc.accountID != "" {
+			id = rc.accountID
+		}
+
+		if rc.zoneID != "" {
+			id = rc.zoneID
+		}
+	} else {
+		switch data[field].(type) {
+		case float64:
+			id = fmt.Sprintf("%d", int(data[field].(float64)))
+		default:
+			id = data[field].(string)
+		}
+	}
+	return id
+}
+
+// buildRawImportAddress takes the resourceType and resourceID in order to look up
+// the resource type import string and then return a suitable address.
+//
+// Note: `importID` is only used on > v4. Otherwise, it is ignored.
+func (rc *runContext) buildRawImportAddress(resourceType, resourceID, importID string) string {
+	address, _ := rc.importAddress(resourceType, resourceID, importID)
+	return address
+}
+
+// importAddress returns the import address of resourceID for the provider
+// version of the run, or false if resourceType has no import format defined.
+// importID is the import ID template of the v5 provider.
+func (rc *runContext) importAddress(resourceType, resourceID, importID string) (string, bool) {
+	if rc.isV5() {
+		return resourceHandlerFor(resourceType).ImportID(rc, resourceID, importID), true
+	}
+
+	s, ok := resourceImportStringFormats[resourceType]
+	if !ok {
+		return "", false
+	}
+
+	var identiferType string
+	var identiferValue string
+
+	if rc.accountID != "" {
+		identiferType = "account"
+		identiferValue = rc.accountID
+	} else {
+		identiferType = "zone"
+		identiferValue = rc.zoneID
+	}
+
+	replacer := strings.NewReplacer(
+		":identifier_type", identiferType,
+		":identifier_value", identiferValue,
+		":zone_id", rc.zoneID,
+		":account_id", rc.accountID,
+		":id", resourceID,
+	)
+
+	return replacer.Replace(s), true
+}
+
+// fetchV4ImportResources fetches the resources of resourceType that import
+// commands are written for with v4 of the provider.
+func (rc *runContext) fetchV4ImportResources(ctx context.Context, resourceType string) ([]interface{}, error) {
+	var jsonStructData []interface{}
+	identifier := rc.scopeIdentifier()
+
+	switch resourceType {
+	case "cloudflare_access_application":
+		jsonPayload, _, err := rc.apiV0.ListAccessApplications(ctx, identifier, cfv0.ListAccessApplicationsParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_access_group":
+		jsonPayload, _, err := rc.apiV0.ListAccessGroups(ctx, identifier, cfv0.ListAccessGroupsParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_access_rule":
+		if rc.accountID != "" {
+			jsonPayload, err := rc.apiV0.ListAccountAccessRules(ctx, rc.accountID, cfv0.AccessRule{}, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			m, _ := json.Marshal(jsonPayload.Result)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			jsonPayload, err := rc.apiV0.ListZoneAccessRules(ctx, rc.zoneID, cfv0.AccessRule{}, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			m, _ := json.Marshal(jsonPayload.Result)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "cloudflare_account_member":
+		jsonPayload, _, err := rc.apiV0.AccountMembers(ctx, rc.accountID, cfv0.PaginationOptions{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_argo":
+		jsonPayload := []cfv0.ArgoFeatureSetting{{
+			ID: fmt.Sprintf("%x", md5.Sum([]byte(time.Now().String()))),
+		}}
+
+		m, _ := json.Marshal(jsonPayload)
+		err := json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_bot_management":
+		botManagement, err := rc.apiV0.GetBotManagement(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		var jsonPayload []cfv0.BotManagement
+		jsonPayload = append(jsonPayload, botManagement)
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+
+		jsonStructData[0].(map[string]interface{})["id"] = rc.zoneID
+	case "cloudflare_byo_ip_prefix":
+		jsonPayload, err := rc.apiV0.ListPrefixes(ctx, rc.accountID)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_certificate_pack":
+		jsonPayload, err := rc.apiV0.ListCertificatePacks(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		var customerManagedCertificates []cfv0.CertificatePack
+		for _, r := range jsonPayload {
+			if r.Type != "universal" {
+				customerManagedCertificates = append(customerManagedCertificates, r)
+			}
+		}
+		jsonPayload = customerManagedCertificates
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_custom_pages":
+		if rc.accountID != "" {
+			jsonPayload, err := rc.apiV0.CustomPages(ctx, &cfv0.CustomPageOptions{AccountID: rc.accountID})
+			if err != nil {
+				return nil, err
+			}
+
+			m, _ := json.Marshal(jsonPayload)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			jsonPayload, err := rc.apiV0.CustomPages(ctx, &cfv0.CustomPageOptions{ZoneID: rc.zoneID})
+			if err != nil {
+				return nil, err
+			}
+
+			m, _ := json.Marshal(jsonPayload)
+			err = json.Unmarshal(m, &jsonStructData)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "cloudflare_filter":
+		jsonPayload, _, err := rc.apiV0.Filters(ctx, identifier, cfv0.FilterListParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_firewall_rule":
+		jsonPayload, _, err := rc.apiV0.FirewallRules(ctx, identifier, cfv0.FirewallRuleListParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_healthcheck":
+		jsonPayload, err := rc.apiV0.Healthchecks(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_custom_hostname":
+		jsonPayload, _, err := rc.apiV0.CustomHostnames(ctx, rc.zoneID, 1, cfv0.CustomHostname{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_custom_ssl":
+		jsonPayload, err := rc.apiV0.ListSSL(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_ip_list":
+		jsonPayload, err := rc.apiV0.ListIPLists(ctx, rc.accountID)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_load_balancer":
+		jsonPayload, err := rc.apiV0.ListLoadBalancers(ctx, identifier, cfv0.ListLoadBalancerParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_load_balancer_pool":
+		jsonPayload, err := rc.apiV0.ListLoadBalancerPools(ctx, identifier, cfv0.ListLoadBalancerPoolParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_load_balancer_monitor":
+		jsonPayload, err := rc.apiV0.ListLoadBalancerMonitors(ctx, identifier, cfv0.ListLoadBalancerMonitorParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_logpush_job":
+		jsonPayload, err := rc.apiV0.ListLogpushJobs(ctx, identifier, cfv0.ListLogpushJobsParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_origin_ca_certificate":
+		jsonPayload, err := rc.originCAClientV0().ListOriginCACertificates(ctx, cfv0.ListOriginCertificatesParams{ZoneID: rc.zoneID})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_page_rule":
+		jsonPayload, err := rc.apiV0.ListPageRules(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_rate_limit":
+		jsonPayload, err := rc.apiV0.ListAllRateLimits(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_record":
+		jsonPayload, _, err := rc.apiV0.ListDNSRecords(ctx, identifier, cfv0.ListDNSRecordsParams{})
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_ruleset":
+		jsonPayload, err := rc.apiV0.ListRulesets(ctx, identifier, cfv0.ListRulesetsParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		// Customers can read-only Managed Rulesets, so we don't want to
+		// have them try to import something they can't manage with terraform
+		var nonManagedRules []cfv0.Ruleset
+		for _, r := range jsonPayload {
+			if r.Kind != string(cfv0.RulesetKindManaged) {
+				nonManagedRules = append(nonManagedRules, r)
+			}
+		}
+
+		m, _ := json.Marshal(nonManagedRules)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_spectrum_application":
+		jsonPayload, err := rc.apiV0.SpectrumApplications(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_teams_list":
+		jsonPayload, _, err := rc.apiV0.ListTeamsLists(ctx, identifier, cfv0.ListTeamListsParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_teams_location":
+		jsonPayload, _, err := rc.apiV0.TeamsLocations(ctx, rc.accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_teams_proxy_endpoint":
+		jsonPayload, _, err := rc.apiV0.TeamsProxyEndpoints(ctx, rc.accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_teams_rule":
+		jsonPayload, err := rc.apiV0.TeamsRules(ctx, rc.accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_tunnel":
+		rc.log.Debug("only requesting the first 1000 active Cloudflare Tunnels due to the service not providing correct pagination responses")
+		jsonPayload, _, err := rc.apiV0.ListTunnels(
+			ctx,
+			cfv0.AccountIdentifier(rc.accountID),
+			cfv0.TunnelListParams{
+				IsDeleted: cfv0.BoolPtr(false),
+				ResultInfo: cfv0.ResultInfo{
+					PerPage: 1000,
+					Page:    1,
+				},
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_turnstile_widget":
+		jsonPayload, _, err := rc.apiV0.ListTurnstileWidgets(ctx, identifier, cfv0.ListTurnstileWidgetParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(jsonStructData); i++ {
+			jsonStructData[i].(map[string]interface{})["id"] = jsonStructData[i].(map[string]interface{})["sitekey"]
+		}
+	case "cloudflare_waf_override":
+		jsonPayload, err := rc.apiV0.ListWAFOverrides(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_waf_package":
+		jsonPayload, err := rc.apiV0.ListWAFPackages(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_waiting_room":
+		jsonPayload, err := rc.apiV0.ListWaitingRooms(ctx, rc.zoneID)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_workers_kv_namespace":
+		jsonPayload, _, err := rc.apiV0.ListWorkersKVNamespaces(ctx, identifier, cfv0.ListWorkersKVNamespacesParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_worker_route":
+		jsonPayload, err := rc.apiV0.ListWorkerRoutes(ctx, identifier, cfv0.ListWorkerRoutesParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload.Routes)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_zone":
+		jsonPayload, err := rc.apiV0.ListZones(ctx)
+		if err != nil {
+			return nil, err
+		}
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	case "cloudflare_zone_lockdown":
+		jsonPayload, _, err := rc.apiV0.ListZoneLockdowns(ctx, identifier, cfv0.LockdownListParams{})
+		if err != nil {
+			return nil, err
+		}
+
+		m, _ := json.Marshal(jsonPayload)
+		err = json.Unmarshal(m, &jsonStructData)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errNotSupported
+	}
+	return jsonStructData, nil
+}
@@ -1,6 +1,8 @@
-package cmd
+package generator
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,8 +15,7 @@ import (
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
 
-	"github.com/spf13/viper"
-
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -143,22 +144,19 @@ func TestResourceImportV5(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			// Reset the environment variables used in test to ensure we don't
-			// have both present at once.
-			viper.Set("zone", "")
-			viper.Set("account", "")
+			t.Parallel()
 
 			var r *recorder.Recorder
 			var err error
 			r, err = recorder.New("../../../../testdata/cloudflare/v5/" + tc.testdataFilename)
 
 			if err != nil {
-				log.Fatal(err)
+				t.Fatal(err)
 			}
 			defer func() {
 				err := r.Stop()
 				if err != nil {
-					log.Fatal(err)
+					t.Fatal(err)
 				}
 			}()
 
@@ -186,47 +184,31 @@ func TestResourceImportV5(t *testing.T) {
 				return nil
 			})
 
-			output := ""
-			api = cloudflare.NewClient(option.WithHTTPClient(
-				&http.Client{
-					Transport: r,
-				},
-			))
+			rc := &runContext{
+				providerVersion: "5.0.0",
+				api: cloudflare.NewClient(option.WithHTTPClient(
+					&http.Client{
+						Transport: r,
+					},
+				)),
+				transformRules: mustParseTransformRules(defaultTransformsYAML),
+				log:            logrus.New(),
+			}
 			if tc.identiferType == "account" {
-				viper.Set("account", cloudflareTestAccountID)
-				if tc.cliFlags != "" {
-					output, err = executeCommandC(
-						rootCmd,
-						"import",
-						"--resource-type",
-						tc.resourceType,
-						"--account",
-						cloudflareTestAccountID,
-						"--resource-id",
-						tc.cliFlags,
-					)
-				} else {
-					output, err = executeCommandC(rootCmd, "import", "--resource-type", tc.resourceType, "--account", cloudflareTestAccountID)
-				}
+				rc.accountID = cloudflareTestAccountID
 			} else {
-				viper.Set("zone", cloudflareTestZoneID)
-				if tc.cliFlags != "" {
-					output, err = executeCommandC(
-						rootCmd,
-						"import",
-						"--resource-type",
-						tc.resourceType,
-						"--zone",
-						cloudflareTestZoneID,
-						"--resource-id",
-						tc.cliFlags,
-					)
-				} else {
-					output, err = executeCommandC(rootCmd, "import", "--resource-type", tc.resourceType, "--zone", cloudflareTestZoneID)
-				}
+				rc.zoneID = cloudflareTestZoneID
+			}
+			if tc.cliFlags != "" {
+				rType, ids, _ := strings.Cut(tc.cliFlags, "=")
+				rc.resourceIDs = map[string][]string{rType: strings.Split(ids, ",")}
 			}
+
+			var buf bytes.Buffer
+			err = rc.importResources(context.Background(), &buf, &buf, []string{tc.resourceType}, false)
+			assert.NoError(t, err)
+			output := buf.String()
 			assert.NotEmpty(t, output, fmt.Sprintf("should have output. But got %s", output))
-			assert.NoError(t, err, fmt.Sprintf("should not have an error. But got %+v", err))
 			assert.True(t, strings.Contains(output, "terraform import"), fmt.Sprintf("should match %s", output))
 		})
 	}
@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// PII redaction modes for `--redact-pii`.
+const (
+	PIIModeHash     = "hash"
+	PIIModeVariable = "variable"
+)
+
+var (
+	// resourcePIIAttributes are the attribute paths, per resource type, that
+	// contain personally identifiable information. Path segments are separated
+	// by dots and lists are traversed element by element. Attribute names
+	// differ between versions of the provider so both are listed.
+	resourcePIIAttributes = map[string][]string{
+		"cloudflare_access_group":                  {"include.email", "exclude.email", "require.email"},
+		"cloudflare_access_policy":                 {"include.email", "exclude.email", "require.email"},
+		"cloudflare_account_member":                {"email", "email_address"},
+		"cloudflare_email_routing_address":         {"email"},
+		"cloudflare_email_routing_catch_all":       {"actions.value", "matchers.value"},
+		"cloudflare_email_routing_rule":            {"action.value", "matcher.value", "actions.value", "matchers.value"},
+		"cloudflare_notification_policy":           {"email_integration.id", "mechanisms.email.id"},
+		"cloudflare_zero_trust_access_application": {"policies.include.email.email", "policies.exclude.email.email", "policies.require.email.email"},
+		"cloudflare_zero_trust_access_group":       {"include.email.email", "exclude.email.email", "require.email.email"},
+		"cloudflare_zero_trust_access_policy":      {"include.email.email", "exclude.email.email", "require.email.email"},
+	}
+)
+
+// variableReference is written as a reference to the named variable instead
+// of a literal value.
+type variableReference struct {
+	name string
+}
+
+func (v variableReference) tokens() hclwrite.Tokens {
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: v.name},
+	})
+}
+
+// piiRedactor replaces PII values for the duration of a run. Equal values are
+// always given the same replacement so that the output stays consistent
+// across resources and runs.
+type piiRedactor struct {
+	mode string
+	// key is the secret replacements are derived from, so that they can't be
+	// reversed by hashing candidate values.
+	key      []byte
+	mapping  map[string]interface{}
+	declared map[string]bool
+	pending  []string
+	log      *logrus.Logger
+}
+
+// newPIIRedactor creates a redactor whose replacements are keyed by the secret
+// stored next to mappingFile, which is generated on first use.
+func newPIIRedactor(mode, mappingFile string, log *logrus.Logger) (*piiRedactor, error) {
+	if mode != PIIModeHash && mode != PIIModeVariable {
+		return nil, fmt.Errorf("unsupported --redact-pii mode %q, must be one of: %s, %s", mode, PIIModeHash, PIIModeVariable)
+	}
+	key, err := loadOrCreatePIIKey(piiKeyFile(mappingFile), log)
+	if err != nil {
+		return nil, err
+	}
+	return &piiRedactor{mode: mode, key: key, mapping: map[string]interface{}{}, declared: map[string]bool{}, log: log}, nil
+}
+
+// piiKeyFile returns the path of the secret kept alongside the mapping file.
+// It isn't part of the mapping so that the mapping can still be used as a
+// Terraform variables file.
+func piiKeyFile(mappingFile string) string {
+	return mappingFile + ".key"
+}
+
+// loadOrCreatePIIKey reads the hex encoded secret at path, generating and
+// writing a random one when the file doesn't exist yet.
+func loadOrCreatePIIKey(path string, log *logrus.Logger) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) == 0 {
+			return nil, fmt.Errorf("invalid PII key in %s", path)
+		}
+		return key, nil
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to read PII key: %w", err)
+	}
+
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write PII key: %w", err)
+	}
+	log.Infof("generated the key for --redact-pii replacements in %s", path)
+	return key, nil
+}
+
+// redact replaces the PII attributes of a single resource in place.
+func (p *piiRedactor) redact(rType string, structData map[string]interface{}) {
+	for _, path := range resourcePIIAttributes[rType] {
+		redactPath(structData, strings.Split(path, "."), p.replace)
+	}
+}
+
+// replace returns the replacement for a single PII value.
+func (p *piiRedactor) replace(value string) interface{} {
+	if value == "" {
+		return value
+	}
+
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(value))
+	name := "pii_" + hex.EncodeToString(mac.Sum(nil)[:6])
+
+	if p.mode == PIIModeVariable {
+		p.mapping[name] = value
+		if !p.declared[name] {
+			p.declared[name] = true
+			p.pending = append(p.pending, name)
+		}
+		return variableReference{name: name}
+	}
+
+	// keep email addresses syntactically valid so the configuration still
+	// passes validation.
+	if strings.Contains(value, "@") {
+		name += "@redacted.invalid"
+	}
+	p.mapping[name] = value
+	return name
+}
+
+// declare appends a variable block for every variable referenced since the
+// last call.
+func (p *piiRedactor) declare(body *hclwrite.Body) {
+	sort.Strings(p.pending)
+	for _, name := range p.pending {
+		block := body.AppendNewBlock("variable", []string{name}).Body()
+		block.SetAttributeRaw("type", hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte("string")}})
+		block.SetAttributeValue("sensitive", cty.True)
+		body.AppendNewline()
+	}
+	p.pending = nil
+}
+
+// writeMapping merges the original values into the JSON file at path. In
+// variable mode, the file can be passed to Terraform with `-var-file`.
+func (p *piiRedactor) writeMapping(path string) error {
+	return mergeJSONFile(p.log, path, p.mapping)
+}
+
+// redactPath walks value along path and replaces every string found at the end
+// of it using replace.
+func redactPath(value interface{}, path []string, replace func(string) interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if len(path) == 0 {
+			return replace(v)
+		}
+	case []string:
+		if len(path) == 0 {
+			out := make([]interface{}, len(v))
+			for i, s := range v {
+				out[i] = replace(s)
+			}
+			return out
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactPath(v[i], path, replace)
+		}
+	case []map[string]interface{}:
+		for i := range v {
+			redactPath(v[i], path, replace)
+		}
+	case map[string]interface{}:
+		if len(path) == 0 {
+			break
+		}
+		if child, ok := v[path[0]]; ok {
+			v[path[0]] = redactPath(child, path[1:], replace)
+		}
+	}
+	return value
+}
+
+// hclExpression is a value that is written as an expression rather than a
+// literal.
+type hclExpression interface {
+	tokens() hclwrite.Tokens
+}
+
+// containsExpression reports whether value or any value nested in it is
+// written as an expression.
+func containsExpression(value interface{}) bool {
+	switch v := value.(type) {
+	case hclExpression:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if containsExpression(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			if containsExpression(item) {
+				return true
+			}
+		}
+	case []map[string]interface{}:
+		for _, item := range v {
+			if containsExpression(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expressionTokens returns the tokens for value, writing nested expressions as
+// is and everything else as literals.
+func expressionTokens(value interface{}) hclwrite.Tokens {
+	switch v := value.(type) {
+	case hclExpression:
+		return v.tokens()
+	case []interface{}:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, item := range v {
+			elems = append(elems, expressionTokens(item))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case []map[string]interface{}:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, item := range v {
+			elems = append(elems, expressionTokens(item))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, k := range keys {
+			name := hclwrite.TokensForValue(cty.StringVal(k))
+			if hclsyntax.ValidIdentifier(k) {
+				name = hclwrite.TokensForIdentifier(k)
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: expressionTokens(v[k])})
+		}
+		return hclwrite.TokensForObject(attrs)
+	default:
+		return hclwrite.TokensForValue(processExpression(value))
+	}
+}
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPIIRedactor creates a redactor with a fixed key.
+func newTestPIIRedactor(t *testing.T, mode string) *piiRedactor {
+	t.Helper()
+	mappingFile := filepath.Join(t.TempDir(), "pii-mapping.json")
+	require.NoError(t, os.WriteFile(piiKeyFile(mappingFile), []byte(strings.Repeat("0f", 32)+"\n"), 0600))
+	p, err := newPIIRedactor(mode, mappingFile, logrus.New())
+	require.NoError(t, err)
+	return p
+}
+
+func TestPIIRedactorHash(t *testing.T) {
+	p := newTestPIIRedactor(t, PIIModeHash)
+
+	policy := map[string]interface{}{
+		"name": "Allow devs",
+		"include": []interface{}{
+			map[string]interface{}{"email": map[string]interface{}{"email": "dev@example.com"}},
+			map[string]interface{}{"everyone": map[string]interface{}{}},
+		},
+	}
+	p.redact("cloudflare_zero_trust_access_policy", policy)
+
+	redacted := policy["include"].([]interface{})[0].(map[string]interface{})["email"].(map[string]interface{})["email"]
+	assert.Equal(t, "pii_9fb89893eb22@redacted.invalid", redacted)
+	assert.Equal(t, "Allow devs", policy["name"])
+	assert.Equal(t, map[string]interface{}{"pii_9fb89893eb22@redacted.invalid": "dev@example.com"}, p.mapping)
+
+	// the same value is always given the same replacement.
+	member := map[string]interface{}{"email": "dev@example.com"}
+	p.redact("cloudflare_account_member", member)
+	assert.Equal(t, redacted, member["email"])
+}
+
+func TestPIIRedactorVariable(t *testing.T) {
+	p := newTestPIIRedactor(t, PIIModeVariable)
+
+	rule := map[string]interface{}{
+		"actions": []interface{}{
+			map[string]interface{}{"type": "forward", "value": []interface{}{"dev@example.com"}},
+		},
+	}
+	p.redact("cloudflare_email_routing_rule", rule)
+
+	f := hclwrite.NewEmptyFile()
+	resource := f.Body().AppendNewBlock("resource", []string{"cloudflare_email_routing_rule", "terraform_managed_resource"}).Body()
+	(&runContext{log: logrus.New()}).writeAttrLine("actions", rule["actions"], "", resource)
+	f.Body().AppendNewline()
+	p.declare(f.Body())
+
+	assert.Equal(t, `resource "cloudflare_email_routing_rule" "terraform_managed_resource" {
+  actions = [{
+    type  = "forward"
+    value = [var.pii_9fb89893eb22]
+  }]
+}
+
+variable "pii_9fb89893eb22" {
+  type      = string
+  sensitive = true
+}
+
+`, string(hclwrite.Format(f.Bytes())))
+
+	// variables are only declared once per run.
+	p.redact("cloudflare_email_routing_address", map[string]interface{}{"email": "dev@example.com"})
+	assert.Empty(t, p.pending)
+
+	path := filepath.Join(t.TempDir(), "pii-mapping.json")
+	assert.NoError(t, p.writeMapping(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var mapping map[string]string
+	assert.NoError(t, json.Unmarshal(data, &mapping))
+	assert.Equal(t, map[string]string{"pii_9fb89893eb22": "dev@example.com"}, mapping)
+}
+
+func TestPIIRedactorKey(t *testing.T) {
+	mappingFile := filepath.Join(t.TempDir(), "pii-mapping.json")
+	p, err := newPIIRedactor(PIIModeHash, mappingFile, logrus.New())
+	require.NoError(t, err)
+	assert.FileExists(t, piiKeyFile(mappingFile))
+	replacement := p.replace("dev@example.com")
+
+	// the replacement isn't the plain hash of the value.
+	sum := sha256.Sum256([]byte("dev@example.com"))
+	assert.NotEqual(t, "pii_"+hex.EncodeToString(sum[:6])+"@redacted.invalid", replacement)
+
+	// the stored key keeps replacements stable across runs.
+	p, err = newPIIRedactor(PIIModeHash, mappingFile, logrus.New())
+	require.NoError(t, err)
+	assert.Equal(t, replacement, p.replace("dev@example.com"))
+
+	// without it, the replacements are different.
+	p, err = newPIIRedactor(PIIModeHash, filepath.Join(t.TempDir(), "pii-mapping.json"), logrus.New())
+	require.NoError(t, err)
+	assert.NotEqual(t, replacement, p.replace("dev@example.com"))
+}
+
+func TestNewPIIRedactorInvalidMode(t *testing.T) {
+	_, err := newPIIRedactor("encrypt", filepath.Join(t.TempDir(), "pii-mapping.json"), logrus.New())
+	assert.Error(t, err)
+}
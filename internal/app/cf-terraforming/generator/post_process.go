@@ -1,4 +1,4 @@
-package cmd
+package generator
 
 import (
 	"strings"
@@ -7,18 +7,9 @@ import (
 	"github.com/hashicorp/hcl/v2/hclwrite"
 )
 
-// postProcess allows you to perform additional actions on the generated hcl.
-func postProcess(f *hclwrite.File, resourceType string) {
-	switch resourceType {
-	case "cloudflare_stream_live_input", "cloudflare_stream":
-		addJSONEncode(f, "meta")
-	case "cloudflare_observatory_scheduled_test":
-		addURLEncode(f, "url")
-	}
-}
-
-// addJSONEncode wraps a hcl block with the jsonencode function.
-func addJSONEncode(f *hclwrite.File, attributeName string) {
+// addJSONEncode wraps attributeName of the rType resource blocks with the
+// jsonencode function.
+func addJSONEncode(f *hclwrite.File, rType, attributeName string) {
 	for _, block := range f.Body().Blocks() {
 		if block.Type() != "resource" {
 			continue
@@ -26,7 +17,7 @@ func addJSONEncode(f *hclwrite.File, attributeName string) {
 		if len(block.Labels()) < 1 {
 			continue
 		}
-		if block.Labels()[0] != resourceType {
+		if block.Labels()[0] != rType {
 			continue
 		}
 		body := block.Body()
@@ -58,8 +49,9 @@ func addJSONEncode(f *hclwrite.File, attributeName string) {
 	}
 }
 
-// addURLEncode wraps a hcl block with the urlencode function.
-func addURLEncode(f *hclwrite.File, attributeName string) {
+// addURLEncode wraps attributeName of the rType resource blocks with the
+// urlencode function.
+func addURLEncode(f *hclwrite.File, rType, attributeName string) {
 	for _, block := range f.Body().Blocks() {
 		if block.Type() != "resource" {
 			continue
@@ -67,7 +59,7 @@ func addURLEncode(f *hclwrite.File, attributeName string) {
 		if len(block.Labels()) < 1 {
 			continue
 		}
-		if block.Labels()[0] != resourceType {
+		if block.Labels()[0] != rType {
 			continue
 		}
 		body := block.Body()
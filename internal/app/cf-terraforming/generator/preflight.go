@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// Preflight statuses for a resource type.
+const (
+	preflightAccessible  = "accessible"
+	preflightDenied      = "denied"
+	preflightNotFound    = "not found"
+	preflightSkipped     = "skipped"
+	preflightUnsupported = "unsupported"
+	preflightError       = "error"
+)
+
+// preflightResult is the outcome of probing a single resource type.
+type preflightResult struct {
+	ResourceType string
+	Status       string
+	Detail       string
+}
+
+// verifyToken calls the token verification endpoint and returns the status of
+// the token. API keys can't be verified this way and are reported as such.
+func (rc *runContext) verifyToken(ctx context.Context) (string, error) {
+	if rc.credentials.apiToken == "" {
+		return "not verified (using API key authentication)", nil
+	}
+
+	endpoints := []string{"/user/tokens/verify"}
+	if rc.accountID != "" {
+		// account owned tokens are only recognised by the account endpoint.
+		endpoints = append(endpoints, "/accounts/"+rc.accountID+"/tokens/verify")
+	}
+
+	var lastErr error
+	for _, endpoint := range endpoints {
+		body, status, err := rc.probeEndpoint(ctx, endpoint)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if status != http.StatusOK {
+			lastErr = fmt.Errorf("%s returned HTTP %d", endpoint, status)
+			continue
+		}
+		return gjson.GetBytes(body, "result.status").String(), nil
+	}
+	return "", lastErr
+}
+
+// preflightResources probes the list endpoint of every resource type with a
+// single item page to determine whether the credentials can read it.
+func (rc *runContext) preflightResources(ctx context.Context, resources []string) []preflightResult {
+	results := make([]preflightResult, 0, len(resources))
+	for _, rType := range resources {
+		result := preflightResult{ResourceType: rType}
+
+		endpoint := rc.resourceEndpoint(rType)
+		if endpoint == "" {
+			result.Status = preflightUnsupported
+			result.Detail = "no API endpoint is known for this resource"
+			results = append(results, result)
+			continue
+		}
+
+		if isOriginCAEndpoint(endpoint) && rc.credentials.originCAKey == "" {
+			result.Status = preflightSkipped
+			result.Detail = "requires --origin-ca-key"
+			results = append(results, result)
+			continue
+		}
+
+		if isSupportedPathParam(resources, rType) {
+			ids := rc.resourceIDs[rType]
+			if len(ids) == 0 {
+				result.Status = preflightSkipped
+				result.Detail = "requires --resource-id"
+				results = append(results, result)
+				continue
+			}
+			endpoint = resourceHandlerFor(rType).DiscoverParents(endpoint, ids[:1])[0]
+		}
+
+		if strings.Contains(endpoint, "{") {
+			result.Status = preflightSkipped
+			result.Detail = "endpoint requires parent identifiers: " + endpoint
+			results = append(results, result)
+			continue
+		}
+
+		rc.log.WithFields(logrus.Fields{
+			"resource": rType,
+			"endpoint": endpoint,
+		}).Debug("running preflight check")
+
+		probe := endpoint
+		if resourceToEndpoint[rType].pagination != paginationNone {
+			probe = appendQueryParam(endpoint, "per_page", "1")
+		}
+		_, status, err := rc.probeEndpoint(ctx, probe)
+		if err == nil && status == http.StatusBadRequest && probe != endpoint {
+			// not every endpoint accepts pagination parameters.
+			_, status, err = rc.probeEndpoint(ctx, endpoint)
+		}
+
+		switch {
+		case err != nil:
+			result.Status = preflightError
+			result.Detail = err.Error()
+		case status == http.StatusUnauthorized || status == http.StatusForbidden:
+			result.Status = preflightDenied
+			result.Detail = fmt.Sprintf("HTTP %d from %s", status, endpoint)
+		case status == http.StatusNotFound:
+			result.Status = preflightNotFound
+			result.Detail = endpoint
+		case status >= 200 && status < 300:
+			result.Status = preflightAccessible
+			result.Detail = endpoint
+		default:
+			result.Status = preflightError
+			result.Detail = fmt.Sprintf("HTTP %d from %s", status, endpoint)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// probeEndpoint performs a live GET request and returns the body and HTTP
+// status. API errors carrying a status code are not returned as errors.
+func (rc *runContext) probeEndpoint(ctx context.Context, endpoint string) ([]byte, int, error) {
+	result := new(http.Response)
+	err := rc.api.Get(ctx, endpoint, nil, &result, rc.endpointRequestOptions(endpoint)...)
+	if err != nil {
+		var apierr *cloudflare.Error
+		if errors.As(err, &apierr) {
+			return nil, apierr.StatusCode, nil
+		}
+		return nil, 0, err
+	}
+
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, result.StatusCode, err
+	}
+	return body, result.StatusCode, nil
+}
+
+// writePreflightTable outputs the preflight results as an aligned table.
+func writePreflightTable(w io.Writer, results []preflightResult) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RESOURCE TYPE\tSTATUS\tDETAIL")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ResourceType, r.Status, r.Detail)
+	}
+	_ = tw.Flush()
+}
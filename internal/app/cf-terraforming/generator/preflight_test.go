@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreflight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/client/v4/user/tokens/verify":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":{"id":"abc","status":"active"}}`))
+		case "/client/v4/zones/" + cloudflareTestZoneID + "/dns_records":
+			assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+		}
+	}))
+	defer server.Close()
+
+	rc := &runContext{zoneID: cloudflareTestZoneID, credentials: credentials{apiToken: "test-token"}, log: logrus.New()}
+	var err error
+	rc.apiV0, rc.api, err = newAPIClients(server.Client(), server.URL+"/client/v4", rc.credentials, false)
+	assert.NoError(t, err)
+
+	status, err := rc.verifyToken(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "active", status)
+
+	results := rc.preflightResources(context.Background(), []string{"cloudflare_dns_record", "cloudflare_page_rule", "cloudflare_zone_setting", "cloudflare_origin_ca_certificate", "cloudflare_not_real"})
+	assert.Equal(t, []preflightResult{
+		{ResourceType: "cloudflare_dns_record", Status: preflightAccessible, Detail: "/zones/" + cloudflareTestZoneID + "/dns_records"},
+		{ResourceType: "cloudflare_page_rule", Status: preflightDenied, Detail: "HTTP 403 from /zones/" + cloudflareTestZoneID + "/pagerules"},
+		{ResourceType: "cloudflare_zone_setting", Status: preflightSkipped, Detail: "requires --resource-id"},
+		{ResourceType: "cloudflare_origin_ca_certificate", Status: preflightSkipped, Detail: "requires --origin-ca-key"},
+		{ResourceType: "cloudflare_not_real", Status: preflightUnsupported, Detail: "no API endpoint is known for this resource"},
+	}, results)
+
+	var buf bytes.Buffer
+	writePreflightTable(&buf, results[:2])
+	assert.Equal(t, "RESOURCE TYPE          STATUS      DETAIL\n"+
+		"cloudflare_dns_record  accessible  /zones/"+cloudflareTestZoneID+"/dns_records\n"+
+		"cloudflare_page_rule   denied      HTTP 403 from /zones/"+cloudflareTestZoneID+"/pagerules\n", buf.String())
+}
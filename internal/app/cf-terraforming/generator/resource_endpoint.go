@@ -0,0 +1,84 @@
+package generator
+
+//go:generate go run ../../../tools/endpointmapping -config=$STAINLESS_CONFIG -openapi=$OPENAPI_SPEC -output=resource_to_endpoint_mapping.go
+
+import "sort"
+
+// resourceScope is the level of the API that resources of a type live at.
+type resourceScope string
+
+const (
+	// scopeNone is used for resources that aren't nested under an account,
+	// zone or user, such as accounts and zones themselves.
+	scopeNone          resourceScope = ""
+	scopeAccount       resourceScope = "account"
+	scopeZone          resourceScope = "zone"
+	scopeAccountOrZone resourceScope = "account_or_zone"
+	scopeUser          resourceScope = "user"
+)
+
+// paginationStyle is how the `list` endpoint of a resource type is paged.
+type paginationStyle string
+
+const (
+	// paginationUnknown follows a cursor when the response has one and page
+	// numbers otherwise.
+	paginationUnknown paginationStyle = ""
+	paginationNone    paginationStyle = "none"
+	paginationPage    paginationStyle = "page"
+	paginationCursor  paginationStyle = "cursor"
+)
+
+// resourceEndpoint describes the API endpoints of a resource type of the v5
+// provider. resourceToEndpoint is generated from the Stainless configuration
+// and OpenAPI specification of the API with `go generate`.
+type resourceEndpoint struct {
+	// list and get are the paths of the `list` and `get` operations. Either
+	// may be empty.
+	list, get string
+
+	scope resourceScope
+
+	// idField is the field of the API response that identifies a resource, or
+	// empty when there is only one resource per account or zone.
+	idField string
+
+	// importID is the template of the import ID. `{id}` is replaced with the
+	// ID of the resource, the other placeholders with the scope of the run.
+	importID string
+
+	pagination paginationStyle
+
+	// parentParams are the path parameters of the endpoint resources are
+	// fetched from besides the account or zone, such as `{list_id}`.
+	parentParams []string
+}
+
+// fetchEndpoint returns the endpoint that all resources of the type are
+// fetched from: the `list` endpoint, or the `get` endpoint when there isn't
+// one since some resources only exist as `get` operations that return several
+// resources.
+func (e resourceEndpoint) fetchEndpoint() string {
+	if e.list != "" {
+		return e.list
+	}
+	return e.get
+}
+
+// ResourceTypes returns every supported resource type in alphabetical order.
+func ResourceTypes() []string {
+	rTypes := make([]string, 0, len(resourceToEndpoint))
+	for rType := range resourceToEndpoint {
+		rTypes = append(rTypes, rType)
+	}
+	sort.Strings(rTypes)
+	return rTypes
+}
+
+// Endpoint returns the endpoint resources of rType are fetched from and
+// whether rType is supported at all. The endpoint is empty when the API has
+// neither a list nor a get operation for rType.
+func Endpoint(rType string) (string, bool) {
+	e, ok := resourceToEndpoint[rType]
+	return e.fetchEndpoint(), ok
+}
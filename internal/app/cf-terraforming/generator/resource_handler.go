@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/tidwall/gjson"
+)
+
+// ResourceHandler customises how a single resource type of the v5 provider is
+// discovered, fetched, transformed, imported and written. Resource types
+// without a registered handler in resourceHandlers use defaultResourceHandler,
+// so a handler only needs to exist for types that differ from the API
+// response. Implementations embed defaultResourceHandler and override the
+// methods they need.
+type ResourceHandler interface {
+	// ParentPlaceholder returns the endpoint placeholder that the IDs given
+	// with `--resource-id` are substituted for, or an empty string when the
+	// resource isn't listed per parent.
+	ParentPlaceholder() string
+
+	// DiscoverParents returns the endpoint to fetch for each parent ID.
+	DiscoverParents(endpoint string, ids []string) []string
+
+	// Fetch returns all resources of rType from endpoints, which were fetched
+	// for the parent IDs in the same position of parents, if any.
+	Fetch(ctx context.Context, rc *runContext, rType string, parents []string, endpoints []string) ([]interface{}, error)
+
+	// ModifyPayload rewrites the raw `result` of a single response before it
+	// is decoded.
+	ModifyPayload(result gjson.Result) string
+
+	// Transform reshapes a page of decoded resources, fetched for parent, to
+	// match the provider schema. The transform rules of the resource type are
+	// applied afterwards.
+	Transform(rc *runContext, rType string, response *[]interface{}, parent string)
+
+	// ImportID returns the ID used to import the resource with resourceID in
+	// the scope of rc. template is the import ID template of the resource
+	// type from resourceToEndpoint.
+	ImportID(rc *runContext, resourceID, template string) string
+
+	// PostProcess makes changes to the generated configuration that can't be
+	// expressed as values, such as wrapping attributes in function calls.
+	PostProcess(rType string, f *hclwrite.File)
+}
+
+// resourceHandlerFor returns the handler registered for rType, or the
+// default handler when there is none.
+func resourceHandlerFor(rType string) ResourceHandler {
+	if h, ok := resourceHandlers[rType]; ok {
+		return h
+	}
+	return defaultResourceHandler{placeholder: parentPlaceholder(rType)}
+}
+
+// parentPlaceholder returns the path parameter of the endpoint of rType that
+// IDs given with `--resource-id` are substituted for. Resources are only
+// fetched per parent ID when the endpoint has a single parent parameter.
+func parentPlaceholder(rType string) string {
+	if params := resourceToEndpoint[rType].parentParams; len(params) == 1 {
+		return params[0]
+	}
+	return ""
+}
+
+// SupportsParentIDs reports whether resources of rType are fetched per parent
+// ID given with `--resource-id`.
+func SupportsParentIDs(rType string) bool {
+	return resourceHandlerFor(rType).ParentPlaceholder() != ""
+}
+
+// fetchResources fetches every resource of rType using its handler. ids are
+// the parent IDs from `--resource-id` for resources that need them.
+func (rc *runContext) fetchResources(ctx context.Context, rType string, ids []string) ([]interface{}, error) {
+	handler := resourceHandlerFor(rType)
+	endpoint := rc.resourceEndpoint(rType)
+	endpoints := []string{endpoint}
+	if len(ids) > 0 {
+		endpoints = handler.DiscoverParents(endpoint, ids)
+	}
+	return handler.Fetch(ctx, rc, rType, ids, endpoints)
+}
+
+// defaultResourceHandler fetches resources from their `list` or `get` endpoint
+// and writes them as returned by the API. When placeholder is set, resources
+// are fetched once per parent ID with the placeholder replaced by the ID.
+type defaultResourceHandler struct {
+	placeholder string
+}
+
+func (h defaultResourceHandler) ParentPlaceholder() string {
+	return h.placeholder
+}
+
+func (h defaultResourceHandler) DiscoverParents(endpoint string, ids []string) []string {
+	endpoints := make([]string, 0, len(ids))
+	for _, id := range ids {
+		endpoints = append(endpoints, strings.NewReplacer(h.placeholder, id).Replace(endpoint))
+	}
+	return endpoints
+}
+
+func (defaultResourceHandler) Fetch(ctx context.Context, rc *runContext, rType string, parents []string, endpoints []string) ([]interface{}, error) {
+	var result *http.Response
+	return rc.getAPIResponse(ctx, result, rType, parents, endpoints...)
+}
+
+func (defaultResourceHandler) ModifyPayload(result gjson.Result) string {
+	return result.String()
+}
+
+func (defaultResourceHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+}
+
+func (defaultResourceHandler) ImportID(rc *runContext, resourceID, template string) string {
+	accountsOrZones, accountOrZoneID := "zones", rc.zoneID
+	if rc.accountID != "" {
+		accountsOrZones, accountOrZoneID = "accounts", rc.accountID
+	}
+	return strings.NewReplacer(
+		"{accounts_or_zones}", accountsOrZones,
+		"{account_or_zone_id}", accountOrZoneID,
+		"{account_id}", rc.accountID,
+		"{zone_id}", rc.zoneID,
+		"{id}", resourceID,
+	).Replace(template)
+}
+
+func (defaultResourceHandler) PostProcess(rType string, f *hclwrite.File) {}
+
+// fileReferenceHandler writes attribute, which the API doesn't return, as a
+// reference to a file that is created with stub as its content.
+type fileReferenceHandler struct {
+	defaultResourceHandler
+	attribute, function, dir, ext, stub string
+}
+
+func (h fileReferenceHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	addFileReference(response, len(*response), rType, h.attribute, h.function, h.dir, h.ext, h.stub)
+}
+
+// jsonEncodeHandler wraps attribute in a call to `jsonencode`.
+type jsonEncodeHandler struct {
+	defaultResourceHandler
+	attribute string
+}
+
+func (h jsonEncodeHandler) PostProcess(rType string, f *hclwrite.File) {
+	addJSONEncode(f, rType, h.attribute)
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestResourceHandlerParentIDs(t *testing.T) {
+	var withParents []string
+	for rType := range resourceToEndpoint {
+		if SupportsParentIDs(rType) {
+			withParents = append(withParents, rType)
+		}
+	}
+	sort.Strings(withParents)
+	assert.Equal(t, []string{
+		"cloudflare_api_shield_operation_schema_validation_settings",
+		"cloudflare_authenticated_origin_pulls",
+		"cloudflare_cloudforce_one_request_priority",
+		"cloudflare_hostname_tls_setting",
+		"cloudflare_list_item",
+		"cloudflare_magic_transit_site_acl",
+		"cloudflare_magic_transit_site_lan",
+		"cloudflare_magic_transit_site_wan",
+		"cloudflare_observatory_scheduled_test",
+		"cloudflare_pages_domain",
+		"cloudflare_queue_consumer",
+		"cloudflare_r2_bucket_cors",
+		"cloudflare_r2_bucket_event_notification",
+		"cloudflare_r2_bucket_lifecycle",
+		"cloudflare_r2_bucket_lock",
+		"cloudflare_r2_bucket_sippy",
+		"cloudflare_r2_custom_domain",
+		"cloudflare_r2_managed_domain",
+		"cloudflare_stream_audio_track",
+		"cloudflare_stream_download",
+		"cloudflare_waiting_room_event",
+		"cloudflare_waiting_room_rules",
+		"cloudflare_web_analytics_rule",
+		"cloudflare_workers_cron_trigger",
+		"cloudflare_workers_deployment",
+		"cloudflare_workers_script_subdomain",
+		"cloudflare_zero_trust_device_custom_profile_local_domain_fallback",
+		"cloudflare_zero_trust_dlp_custom_profile",
+		"cloudflare_zero_trust_dlp_predefined_profile",
+		"cloudflare_zero_trust_tunnel_cloudflared_config",
+		"cloudflare_zone_setting",
+	}, withParents)
+
+	// endpoints with several parents can't be fetched per ID.
+	assert.False(t, SupportsParentIDs("cloudflare_workers_kv"))
+	assert.False(t, SupportsParentIDs("cloudflare_dns_record"))
+	assert.Equal(t,
+		[]string{"/zones/z/settings/always_online", "/zones/z/settings/cache_level"},
+		resourceHandlerFor("cloudflare_zone_setting").DiscoverParents("/zones/z/settings/{setting_id}", []string{"always_online", "cache_level"}),
+	)
+	assert.Equal(t,
+		[]string{"/zones/z/speed_api/schedule/example.com%2Fpath"},
+		resourceHandlerFor("cloudflare_observatory_scheduled_test").DiscoverParents("/zones/z/speed_api/schedule/{url}", []string{"example.com/path"}),
+	)
+}
+
+func TestResourceHandlerModifyPayload(t *testing.T) {
+	result := gjson.Parse(`{"name":"org"}`)
+	assert.Equal(t, `[{"name":"org"}]`, resourceHandlerFor("cloudflare_zero_trust_organization").ModifyPayload(result))
+	assert.Equal(t, `{"name":"org"}`, resourceHandlerFor("cloudflare_dns_record").ModifyPayload(result))
+}
+
+func TestResourceHandlerImportID(t *testing.T) {
+	rc := &runContext{zoneID: "z"}
+
+	h := resourceHandlerFor("cloudflare_dns_record")
+	assert.Equal(t, "z/1", h.ImportID(rc, "1", "{zone_id}/{id}"))
+	assert.Equal(t, "zones/z/1", h.ImportID(rc, "1", "{accounts_or_zones}/{account_or_zone_id}/{id}"))
+	assert.Equal(t, "accounts/a/1", h.ImportID(&runContext{accountID: "a"}, "1", resourceToEndpoint["cloudflare_ruleset"].importID))
+}
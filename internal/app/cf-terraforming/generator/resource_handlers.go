@@ -0,0 +1,526 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// resourceHandlers are the handlers for resource types of the v5 provider
+// that differ from the defaults. Changes to the response that only move, set
+// or remove attributes are transform rules in transforms.yaml instead.
+var resourceHandlers = map[string]ResourceHandler{
+	"cloudflare_account_member":                                          accountMemberHandler{},
+	"cloudflare_account_subscription":                                    accountSubscriptionHandler{},
+	"cloudflare_authenticated_origin_pulls":                              authenticatedOriginPullsHandler{defaultResourceHandler{placeholder: parentPlaceholder("cloudflare_authenticated_origin_pulls")}},
+	"cloudflare_authenticated_origin_pulls_certificate":                  fileReferenceHandler{attribute: "private_key", function: "file", dir: "certs", ext: ".key.pem", stub: pemPrivateKeyStub},
+	"cloudflare_content_scanning_expression":                             contentScanningExpressionHandler{},
+	"cloudflare_dns_record":                                              dnsRecordHandler{},
+	"cloudflare_filter":                                                  filterHandler{},
+	"cloudflare_keyless_certificate":                                     fileReferenceHandler{attribute: "certificate", function: "file", dir: "certs", ext: ".pem", stub: pemCertificateStub},
+	"cloudflare_observatory_scheduled_test":                              observatoryScheduledTestHandler{defaultResourceHandler{placeholder: parentPlaceholder("cloudflare_observatory_scheduled_test")}},
+	"cloudflare_page_rule":                                               pageRuleHandler{},
+	"cloudflare_ruleset":                                                 rulesetHandler{},
+	"cloudflare_snippet_rules":                                           snippetRulesHandler{},
+	"cloudflare_snippets":                                                snippetsHandler{},
+	"cloudflare_stream":                                                  jsonEncodeHandler{attribute: "meta"},
+	"cloudflare_stream_live_input":                                       jsonEncodeHandler{attribute: "meta"},
+	"cloudflare_stream_watermark":                                        fileReferenceHandler{attribute: "file", function: "filebase64", dir: "watermarks", ext: ".png"},
+	"cloudflare_web_analytics_site":                                      webAnalyticsSiteHandler{},
+	"cloudflare_zero_trust_access_custom_page":                           accessCustomPageHandler{},
+	"cloudflare_zero_trust_access_mtls_certificate":                      fileReferenceHandler{attribute: "certificate", function: "file", dir: "certs", ext: ".pem", stub: pemCertificateStub},
+	"cloudflare_zero_trust_device_default_profile_local_domain_fallback": localDomainFallbackHandler{},
+	"cloudflare_zero_trust_gateway_settings":                             gatewaySettingsHandler{},
+	"cloudflare_zero_trust_organization":                                 zeroTrustOrganizationHandler{},
+}
+
+// accountMemberHandler moves the email address of the user to the top level and lists roles by ID only.
+type accountMemberHandler struct {
+	defaultResourceHandler
+}
+
+func (h accountMemberHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	// remap email and role_ids into the right structure and remove policies
+	for i := 0; i < resourceCount; i++ {
+		delete((*response)[i].(map[string]interface{}), "policies")
+		(*response)[i].(map[string]interface{})["email"] = (*response)[i].(map[string]interface{})["user"].(map[string]interface{})["email"]
+		roleIDs := []string{}
+		for _, role := range (*response)[i].(map[string]interface{})["roles"].([]interface{}) {
+			roleIDs = append(roleIDs, role.(map[string]interface{})["id"].(string))
+		}
+		(*response)[i].(map[string]interface{})["roles"] = roleIDs
+	}
+}
+
+// contentScanningExpressionHandler wraps the payload in a `body` block.
+type contentScanningExpressionHandler struct {
+	defaultResourceHandler
+}
+
+func (h contentScanningExpressionHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	// wrap the response in 'body' for tf
+	for i := 0; i < resourceCount; i++ {
+		payload := (*response)[i].(map[string]interface{})["payload"]
+		(*response)[i].(map[string]interface{})["body"] = []interface{}{map[string]interface{}{
+			"payload": payload,
+		}}
+	}
+}
+
+// localDomainFallbackHandler wraps every domain in a `domains` list.
+type localDomainFallbackHandler struct {
+	defaultResourceHandler
+}
+
+func (h localDomainFallbackHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	// wrap the response in 'domains' for tf
+	for i := 0; i < resourceCount; i++ {
+		do := make(map[string]interface{})
+		do["domains"] = []interface{}{(*response)[i]}
+		(*response)[i] = do
+	}
+}
+
+// gatewaySettingsHandler removes computed attributes from the settings.
+type gatewaySettingsHandler struct {
+	defaultResourceHandler
+}
+
+func (h gatewaySettingsHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	for i := 0; i < resourceCount; i++ {
+		settings, ok := (*response)[i].(map[string]interface{})["settings"]
+		if !ok {
+			return
+		}
+		customCert, ok := settings.(map[string]interface{})["custom_certificate"]
+		if ok {
+			delete(customCert.(map[string]interface{}), "binding_status")
+			delete(customCert.(map[string]interface{}), "expires_on")
+			delete(customCert.(map[string]interface{}), "updated_at")
+		}
+		blockPage, ok := settings.(map[string]interface{})["block_page"]
+		if ok && blockPage != nil {
+			mode, ok := blockPage.(map[string]interface{})["mode"]
+			if ok && mode.(string) == "" {
+				delete(blockPage.(map[string]interface{}), "mode")
+			}
+		}
+	}
+}
+
+// pageRuleHandler maps the targets and actions to the structure used by the provider.
+type pageRuleHandler struct {
+	defaultResourceHandler
+}
+
+func (h pageRuleHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	for i := 0; i < resourceCount; i++ {
+		(*response)[i].(map[string]interface{})["target"] = (*response)[i].(map[string]interface{})["targets"].([]interface{})[0].(map[string]interface{})["constraint"].(map[string]interface{})["value"]
+		(*response)[i].(map[string]interface{})["actions"] = rc.flattenAttrMap((*response)[i].(map[string]interface{})["actions"].([]interface{}))
+
+		// Have to remap the cache_ttl_by_status to conform to Terraform's more human-friendly structure.
+		if cache, ok := (*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_ttl_by_status"].(map[string]interface{}); ok {
+			cacheTtlByStatus := []map[string]interface{}{}
+
+			for codes, ttl := range cache {
+				if ttl == "no-cache" {
+					ttl = 0
+				} else if ttl == "no-store" {
+					ttl = -1
+				}
+				elem := map[string]interface{}{
+					"codes": codes,
+					"ttl":   ttl,
+				}
+
+				cacheTtlByStatus = append(cacheTtlByStatus, elem)
+			}
+
+			sort.SliceStable(cacheTtlByStatus, func(i int, j int) bool {
+				return cacheTtlByStatus[i]["codes"].(string) < cacheTtlByStatus[j]["codes"].(string)
+			})
+
+			(*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_ttl_by_status"] = cacheTtlByStatus
+		}
+
+		// Remap cache_key_fields.query_string.include & .exclude wildcards (not in an array) to the appropriate "ignore" field value in Terraform.
+		if c, ok := (*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{}); ok {
+			if s, sok := c["query_string"].(map[string]interface{})["include"].(string); sok && s == "*" {
+				(*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["include"] = nil
+				(*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["ignore"] = false
+			}
+			if s, sok := c["query_string"].(map[string]interface{})["exclude"].(string); sok && s == "*" {
+				(*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["exclude"] = nil
+				(*response)[i].(map[string]interface{})["actions"].(map[string]interface{})["cache_key_fields"].(map[string]interface{})["query_string"].(map[string]interface{})["ignore"] = true
+			}
+		}
+	}
+}
+
+// authenticatedOriginPullsHandler moves the per hostname settings into a `config` list.
+type authenticatedOriginPullsHandler struct {
+	defaultResourceHandler
+}
+
+func (h authenticatedOriginPullsHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	for i := 0; i < resourceCount; i++ {
+		hName := (*response)[i].(map[string]interface{})["hostname"]
+		cID := (*response)[i].(map[string]interface{})["cert_id"]
+		enabled := (*response)[i].(map[string]interface{})["enabled"]
+		(*response)[i].(map[string]interface{})["config"] = []interface{}{
+			map[string]interface{}{
+				"hostname": hName,
+				"cert_id":  cID,
+				"enabled":  enabled,
+			},
+		}
+	}
+}
+
+// rulesetHandler maps rules to the structure used by the provider and drops computed defaults.
+type rulesetHandler struct {
+	defaultResourceHandler
+}
+
+func (h rulesetHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	ruleHeaders := map[string][]map[string]interface{}{}
+	for i, ruleset := range *response {
+		if ruleset.(map[string]interface{})["rules"] != nil {
+			for j, rule := range ruleset.(map[string]interface{})["rules"].([]interface{}) {
+				ID := rule.(map[string]interface{})["id"]
+				if ID != nil {
+					headers, exists := ruleHeaders[ID.(string)]
+					if exists {
+						(*response)[i].(map[string]interface{})["rules"].([]interface{})[j].(map[string]interface{})["action_parameters"].(map[string]interface{})["headers"] = headers
+					}
+				}
+			}
+		}
+	}
+
+	// log custom fields specific transformation fields
+	logCustomFieldsTransform := []string{"cookie_fields", "request_fields", "response_fields"}
+
+	for i := 0; i < resourceCount; i++ {
+		rules := (*response)[i].(map[string]interface{})["rules"]
+		if rules != nil {
+			for ruleCounter := range rules.([]interface{}) {
+				// should the `ref` be the default `id`, don't output it
+				// as we don't need to track a computed default.
+				id := rules.([]interface{})[ruleCounter].(map[string]interface{})["id"]
+				ref := rules.([]interface{})[ruleCounter].(map[string]interface{})["ref"]
+				if id == ref {
+					rules.([]interface{})[ruleCounter].(map[string]interface{})["ref"] = nil
+				}
+
+				actionParams := rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"]
+				if actionParams != nil {
+					// check for log custom fields that need to be transformed
+					for _, logCustomFields := range logCustomFieldsTransform {
+						// check if the field exists and make sure it has at least one element
+						if actionParams.(map[string]interface{})[logCustomFields] != nil && len(actionParams.(map[string]interface{})[logCustomFields].([]interface{})) > 0 {
+							// Create a new list to store the data in.
+							var newLogCustomFields []interface{}
+							// iterate over each of the keys and add them to a generic list
+							for logCustomFieldsCounter := range actionParams.(map[string]interface{})[logCustomFields].([]interface{}) {
+								newLogCustomFields = append(newLogCustomFields, map[string]interface{}{"name": actionParams.(map[string]interface{})[logCustomFields].([]interface{})[logCustomFieldsCounter].(map[string]interface{})["name"]})
+							}
+							actionParams.(map[string]interface{})[logCustomFields] = newLogCustomFields
+						}
+					}
+
+					// check if our ruleset is of action 'skip'
+					if rules.([]interface{})[ruleCounter].(map[string]interface{})["action"] == "skip" && (*response)[i].(map[string]interface{})["phase"] != "http_request_firewall_managed" {
+						for rule := range actionParams.(map[string]interface{}) {
+							// "rules" is the only map[string][]string we need to remap. The others are all []string and are handled naturally.
+							if rule == "rules" {
+								for key, value := range actionParams.(map[string]interface{})[rule].(map[string]interface{}) {
+									var rulesList []string
+									for _, val := range value.([]interface{}) {
+										rulesList = append(rulesList, val.(string))
+									}
+									actionParams.(map[string]interface{})[rule].(map[string]interface{})[key] = strings.Join(rulesList, ",")
+								}
+							}
+						}
+					}
+
+					// Convert empty rules map to rulesets list
+					// When action_parameters.rules is a map with all empty arrays,
+					// convert it to action_parameters.rulesets (a list of the map keys)
+					if rulesField, hasRules := actionParams.(map[string]interface{})["rules"]; hasRules {
+						if rulesMap, isMap := rulesField.(map[string]interface{}); isMap {
+							allEmpty := true
+							var rulesetIds []string
+
+							// Check if all values are empty arrays
+							for key, value := range rulesMap {
+								rulesetIds = append(rulesetIds, key)
+								if valueArray, ok := value.([]interface{}); ok {
+									if len(valueArray) > 0 {
+										allEmpty = false
+										break
+									}
+								} else {
+									// If value is not an array, don't convert
+									allEmpty = false
+									break
+								}
+							}
+
+							// If all arrays are empty, convert to rulesets
+							if allEmpty && len(rulesetIds) > 0 {
+								// Sort for deterministic output
+								sort.Strings(rulesetIds)
+								// Convert to []interface{} for consistency
+								var rulesetsInterface []interface{}
+								for _, id := range rulesetIds {
+									rulesetsInterface = append(rulesetsInterface, id)
+								}
+								actionParams.(map[string]interface{})["rulesets"] = rulesetsInterface
+								delete(actionParams.(map[string]interface{}), "rules")
+							}
+						}
+					}
+
+					// Cache Rules transformation
+					if (*response)[i].(map[string]interface{})["phase"] == "http_request_cache_settings" {
+						if ck, ok := rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"]; ok {
+							if c, cok := ck.(map[string]interface{})["custom_key"]; cok {
+								if qs, qok := c.(map[string]interface{})["query_string"]; qok {
+									if s, sok := qs.(map[string]interface{})["include"]; sok && s == "*" {
+										rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"].(map[string]interface{})["custom_key"].(map[string]interface{})["query_string"].(map[string]interface{})["include"] = map[string]interface{}{"list": []string{"*"}}
+									}
+									if s, sok := qs.(map[string]interface{})["exclude"]; sok && s == "*" {
+										rules.([]interface{})[ruleCounter].(map[string]interface{})["action_parameters"].(map[string]interface{})["cache_key"].(map[string]interface{})["custom_key"].(map[string]interface{})["query_string"].(map[string]interface{})["exclude"] = map[string]interface{}{"list": []string{"*"}}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dnsRecordHandler drops `content` in favour of `data` for record types that use it.
+type dnsRecordHandler struct {
+	defaultResourceHandler
+}
+
+func (h dnsRecordHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	for i := 0; i < resourceCount; i++ {
+		if _, hasData := (*response)[i].(map[string]interface{})["data"]; hasData {
+			delete((*response)[i].(map[string]interface{}), "content")
+		}
+	}
+}
+
+// webAnalyticsSiteHandler moves the ruleset settings to the top level.
+type webAnalyticsSiteHandler struct {
+	defaultResourceHandler
+}
+
+func (h webAnalyticsSiteHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	for i := 0; i < resourceCount; i++ {
+		if rs, hasRuleSet := (*response)[i].(map[string]interface{})["ruleset"]; hasRuleSet {
+			if enabled, ok := rs.(map[string]interface{})["enabled"]; ok {
+				(*response)[i].(map[string]interface{})["enabled"] = enabled
+			}
+			if zoneTag, ok := rs.(map[string]interface{})["zone_tag"]; ok {
+				(*response)[i].(map[string]interface{})["zone_tag"] = zoneTag
+			}
+			if lite, ok := rs.(map[string]interface{})["lite"]; ok {
+				(*response)[i].(map[string]interface{})["lite"] = lite
+			}
+		}
+	}
+}
+
+// snippetRulesHandler combines all snippet rules into a single resource.
+type snippetRulesHandler struct {
+	defaultResourceHandler
+}
+
+func (h snippetRulesHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	// Transform the array of snippet rule objects into a single resource with a 'rules' array
+	// The API returns multiple snippet rules, but Terraform expects them wrapped in a 'rules' array
+	rules := make([]interface{}, 0, resourceCount)
+	for i := 0; i < resourceCount; i++ {
+		rule := (*response)[i].(map[string]interface{})
+		// Extract only the fields we need for Terraform
+		transformedRule := map[string]interface{}{
+			"expression":   rule["expression"],
+			"snippet_name": rule["snippet_name"],
+			"description":  rule["description"],
+			"enabled":      rule["enabled"],
+		}
+		rules = append(rules, transformedRule)
+	}
+	// Replace the response with a single object containing the rules array
+	*response = []interface{}{
+		map[string]interface{}{
+			"rules": rules,
+		},
+	}
+}
+
+// snippetsHandler moves `main_module` into the `metadata` block.
+type snippetsHandler struct {
+	defaultResourceHandler
+}
+
+func (h snippetsHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	resourceCount := len(*response)
+	// Transform main_module field into metadata block
+	for i := 0; i < resourceCount; i++ {
+		snippet := (*response)[i].(map[string]interface{})
+		if mainModule, ok := snippet["main_module"]; ok {
+			// Create metadata object with main_module
+			snippet["metadata"] = map[string]interface{}{
+				"main_module": mainModule,
+			}
+			// Remove main_module from top level since it's now in metadata
+			delete(snippet, "main_module")
+		}
+	}
+}
+
+// accessCustomPageHandler fetches every custom page one by one as the list
+// endpoint doesn't return `custom_html`.
+type accessCustomPageHandler struct {
+	defaultResourceHandler
+}
+
+func (h accessCustomPageHandler) Fetch(ctx context.Context, rc *runContext, rType string, parents []string, endpoints []string) ([]interface{}, error) {
+	response, err := h.defaultResourceHandler.Fetch(ctx, rc, rType, parents, endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointFMT := resourceToEndpoint[rType].get
+	placeholderReplacer := strings.NewReplacer("{account_id}", rc.accountID)
+	endpointFMT = placeholderReplacer.Replace(endpointFMT)
+	for i := range response {
+		uid, ok := response[i].(map[string]interface{})["uid"]
+		if !ok {
+			continue
+		}
+		endpoint := strings.Replace(endpointFMT, "{custom_page_id}", uid.(string), 1)
+		result := new(http.Response)
+		body, err := rc.fetchEndpoint(ctx, result, rType, endpoint)
+		if err != nil {
+			if isNotFound(err) {
+				rc.log.WithFields(logrus.Fields{
+					"resource": rType,
+					"endpoint": endpoint,
+				}).Debug("no resources found")
+			}
+			return nil, fmt.Errorf("failed to fetch API endpoint: %w", err)
+		}
+		value := gjson.Get(string(body), "result")
+		if value.Type == gjson.Null {
+			rc.log.WithFields(logrus.Fields{
+				"resource": rType,
+				"endpoint": endpoint,
+			}).Debug("no result found")
+			continue
+		}
+		customHTML := gjson.Get(value.Raw, "custom_html")
+		if value.Type == gjson.Null {
+			continue
+		}
+		response[i].(map[string]interface{})["custom_html"] = customHTML.String()
+	}
+	return response, nil
+}
+
+// filterHandler moves the expression and paused state into a `body` block.
+type filterHandler struct {
+	defaultResourceHandler
+}
+
+func (h filterHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	for i := range *response {
+		filterData := (*response)[i].(map[string]interface{})
+
+		// Create body array with filter attributes
+		body := map[string]interface{}{
+			"expression": filterData["expression"],
+			"paused":     filterData["paused"],
+		}
+
+		// Set body as an array
+		filterData["body"] = []interface{}{body}
+
+		// Remove individual attributes from top level
+		delete(filterData, "expression")
+		delete(filterData, "paused")
+	}
+}
+
+// accountSubscriptionHandler removes the computed fields of the rate plan.
+type accountSubscriptionHandler struct {
+	defaultResourceHandler
+}
+
+func (h accountSubscriptionHandler) Transform(rc *runContext, rType string, response *[]interface{}, parent string) {
+	for i := range *response {
+		subscriptionData := (*response)[i].(map[string]interface{})
+
+		if ratePlan, ok := subscriptionData["rate_plan"].(map[string]interface{}); ok {
+			// Keep only id and scope, remove all other fields
+			cleanedRatePlan := map[string]interface{}{
+				"id":    ratePlan["id"],
+				"scope": ratePlan["scope"],
+			}
+			subscriptionData["rate_plan"] = cleanedRatePlan
+		}
+	}
+}
+
+// observatoryScheduledTestHandler fetches scheduled tests by their URL, which
+// has to be escaped in both the endpoint and the configuration.
+type observatoryScheduledTestHandler struct {
+	defaultResourceHandler
+}
+
+func (h observatoryScheduledTestHandler) DiscoverParents(endpoint string, ids []string) []string {
+	escaped := make([]string, 0, len(ids))
+	for _, id := range ids {
+		escaped = append(escaped, url.QueryEscape(id))
+	}
+	return h.defaultResourceHandler.DiscoverParents(endpoint, escaped)
+}
+
+func (h observatoryScheduledTestHandler) PostProcess(rType string, f *hclwrite.File) {
+	addURLEncode(f, rType, "url")
+}
+
+// zeroTrustOrganizationHandler treats the single organization returned by the
+// API as a list.
+type zeroTrustOrganizationHandler struct {
+	defaultResourceHandler
+}
+
+func (h zeroTrustOrganizationHandler) ModifyPayload(result gjson.Result) string {
+	return transformToCollection(result.String())
+}
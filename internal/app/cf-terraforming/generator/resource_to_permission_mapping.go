@@ -0,0 +1,610 @@
+// This file is maintained by hand. When `go generate` adds a resource type to
+// resource_to_endpoint_mapping.go, add the API token permission groups needed
+// to read it here.
+package generator
+
+// PermissionGroups returns the API token permission groups, keyed by scope,
+// that are needed to read resources of rType and whether they are known.
+func PermissionGroups(rType string) (map[string][]string, bool) {
+	groups, ok := resourceToPermissionGroups[rType]
+	return groups, ok
+}
+
+// NeedsOriginCAKey reports whether resources of rType are read with the
+// Origin CA key rather than with any API token permission group.
+func NeedsOriginCAKey(rType string) bool {
+	return originCAKeyResources[rType]
+}
+
+// originCAKeyResources are the resource types that are read from the Origin CA
+// endpoints, which are authorised by the Origin CA key (--origin-ca-key) rather
+// than by an API token permission group.
+var originCAKeyResources = map[string]bool{
+	"cloudflare_origin_ca_certificate": true,
+}
+
+// resourceToPermissionGroups maps each resource type to the API token
+// permission groups, by scope, that are needed to read it from the endpoint in
+// resourceToEndpoint.
+var resourceToPermissionGroups = map[string]map[string][]string{
+	"cloudflare_account": {
+		"account": {"Account Settings Read"},
+	},
+	"cloudflare_account_member": {
+		"account": {"Account Settings Read"},
+	},
+	"cloudflare_account_subscription": {
+		"account": {"Billing Read"},
+	},
+	"cloudflare_account_token": {
+		"account": {"Account API Tokens Read"},
+	},
+	"cloudflare_user": {
+		"user": {"User Details Read"},
+	},
+	"cloudflare_api_token": {
+		"user": {"API Tokens Read"},
+	},
+	"cloudflare_zone": {
+		"zone": {"Zone Read"},
+	},
+	"cloudflare_zone_setting": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_zone_hold": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_zone_subscription": {
+		"zone": {"Zone Read"},
+	},
+	"cloudflare_load_balancer": {
+		"zone": {"Load Balancers Read"},
+	},
+	"cloudflare_load_balancer_monitor": {
+		"account": {"Load Balancing: Monitors and Pools Read"},
+	},
+	"cloudflare_load_balancer_pool": {
+		"account": {"Load Balancing: Monitors and Pools Read"},
+	},
+	"cloudflare_zone_cache_reserve": {
+		"zone": {"Cache Settings Read"},
+	},
+	"cloudflare_tiered_cache": {
+		"zone": {"Cache Settings Read"},
+	},
+	"cloudflare_zone_cache_variants": {
+		"zone": {"Cache Settings Read"},
+	},
+	"cloudflare_regional_tiered_cache": {
+		"zone": {"Cache Settings Read"},
+	},
+	"cloudflare_certificate_pack": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_total_tls": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_argo_smart_routing": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_argo_tiered_caching": {
+		"zone": {"Cache Settings Read"},
+	},
+	"cloudflare_custom_ssl": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_custom_hostname": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_custom_hostname_fallback_origin": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_dns_firewall": {
+		"account": {"DNS Firewall Read"},
+	},
+	"cloudflare_zone_dnssec": {
+		"zone": {"DNS Read"},
+	},
+	"cloudflare_dns_record": {
+		"zone": {"DNS Read"},
+	},
+	"cloudflare_zone_dns_settings": {
+		"zone": {"DNS Read"},
+	},
+	"cloudflare_account_dns_settings": {
+		"account": {"DNS Settings Read"},
+	},
+	"cloudflare_account_dns_settings_internal_view": {
+		"account": {"DNS Views Read"},
+	},
+	"cloudflare_dns_zone_transfers_incoming": {
+		"zone": {"Zone Transfer Read"},
+	},
+	"cloudflare_dns_zone_transfers_outgoing": {
+		"zone": {"Zone Transfer Read"},
+	},
+	"cloudflare_dns_zone_transfers_acl": {
+		"account": {"Account DNS Settings Read"},
+	},
+	"cloudflare_dns_zone_transfers_peer": {
+		"account": {"Account DNS Settings Read"},
+	},
+	"cloudflare_dns_zone_transfers_tsig": {
+		"account": {"Account DNS Settings Read"},
+	},
+	"cloudflare_email_security_block_sender": {
+		"account": {"Cloud Email Security: Read"},
+	},
+	"cloudflare_email_security_impersonation_registry": {
+		"account": {"Cloud Email Security: Read"},
+	},
+	"cloudflare_email_security_trusted_domains": {
+		"account": {"Cloud Email Security: Read"},
+	},
+	"cloudflare_email_routing_settings": {
+		"zone": {"Email Routing Rules Read"},
+	},
+	"cloudflare_email_routing_dns": {
+		"zone": {"Email Routing Rules Read"},
+	},
+	"cloudflare_email_routing_rule": {
+		"zone": {"Email Routing Rules Read"},
+	},
+	"cloudflare_email_routing_catch_all": {
+		"zone": {"Email Routing Rules Read"},
+	},
+	"cloudflare_email_routing_address": {
+		"account": {"Email Routing Addresses Read"},
+	},
+	"cloudflare_filter": {
+		"zone": {"Firewall Services Read"},
+	},
+	"cloudflare_zone_lockdown": {
+		"zone": {"Firewall Services Read"},
+	},
+	"cloudflare_access_rule": {
+		"account": {"Account Firewall Access Rules Read"},
+		"zone":    {"Firewall Services Read"},
+	},
+	"cloudflare_user_agent_blocking_rule": {
+		"zone": {"Firewall Services Read"},
+	},
+	"cloudflare_healthcheck": {
+		"zone": {"Health Checks Read"},
+	},
+	"cloudflare_keyless_certificate": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_logpush_job": {
+		"account": {"Logs Read"},
+		"zone":    {"Logs Read"},
+	},
+	"cloudflare_logpush_ownership_challenge": {
+		"account": {"Logs Read"},
+		"zone":    {"Logs Read"},
+	},
+	"cloudflare_logpull_retention": {
+		"zone": {"Logs Read"},
+	},
+	"cloudflare_authenticated_origin_pulls_certificate": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_authenticated_origin_pulls": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_authenticated_origin_pulls_settings": {
+		"zone": {"SSL and Certificates Read"},
+	},
+	"cloudflare_page_rule": {
+		"zone": {"Page Rules Read"},
+	},
+	"cloudflare_rate_limit": {
+		"zone": {"Firewall Services Read"},
+	},
+	"cloudflare_waiting_room": {
+		"zone": {"Waiting Rooms Read"},
+	},
+	"cloudflare_waiting_room_event": {
+		"zone": {"Waiting Rooms Read"},
+	},
+	"cloudflare_waiting_room_rules": {
+		"zone": {"Waiting Rooms Read"},
+	},
+	"cloudflare_waiting_room_settings": {
+		"zone": {"Waiting Rooms Read"},
+	},
+	"cloudflare_web3_hostname": {
+		"zone": {"Web3 Hostnames Read"},
+	},
+	"cloudflare_workers_route": {
+		"zone": {"Workers Routes Read"},
+	},
+	"cloudflare_workers_script_subdomain": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_workers_cron_trigger": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_workers_deployment": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_workers_custom_domain": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_workers_kv_namespace": {
+		"account": {"Workers KV Storage Read"},
+	},
+	"cloudflare_workers_kv": {
+		"account": {"Workers KV Storage Read"},
+	},
+	"cloudflare_queue": {
+		"account": {"Queues Read"},
+	},
+	"cloudflare_queue_consumer": {
+		"account": {"Queues Read"},
+	},
+	"cloudflare_api_shield": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_api_shield_discovery_operation": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_api_shield_operation": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_api_shield_operation_schema_validation_settings": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_api_shield_schema_validation_settings": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_api_shield_schema": {
+		"zone": {"API Gateway Read"},
+	},
+	"cloudflare_managed_transforms": {
+		"zone": {"Transform Rules Read"},
+	},
+	"cloudflare_page_shield_policy": {
+		"zone": {"Page Shield Read"},
+	},
+	"cloudflare_ruleset": {
+		"account": {"Account Rulesets Read"},
+		"zone":    {"Zone WAF Read", "Transform Rules Read", "Cache Rules Read", "Config Rules Read", "Dynamic URL Redirects Read", "Origin Read", "Custom Errors Read", "Logs Read"},
+	},
+	"cloudflare_url_normalization_settings": {
+		"zone": {"Transform Rules Read"},
+	},
+	"cloudflare_spectrum_application": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_regional_hostname": {
+		"zone": {"DNS Read"},
+	},
+	"cloudflare_address_map": {
+		"account": {"IP Prefixes: Read"},
+	},
+	"cloudflare_byo_ip_prefix": {
+		"account": {"IP Prefixes: Read"},
+	},
+	"cloudflare_image": {
+		"account": {"Cloudflare Images Read"},
+	},
+	"cloudflare_image_variant": {
+		"account": {"Cloudflare Images Read"},
+	},
+	"cloudflare_magic_wan_gre_tunnel": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_wan_ipsec_tunnel": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_wan_static_route": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_transit_site": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_transit_site_acl": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_transit_site_lan": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_transit_site_wan": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_transit_connector": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_network_monitoring_configuration": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_magic_network_monitoring_rule": {
+		"account": {"Magic Transit Read"},
+	},
+	"cloudflare_mtls_certificate": {
+		"account": {"Account: SSL and Certificates Read"},
+	},
+	"cloudflare_pages_project": {
+		"account": {"Pages Read"},
+	},
+	"cloudflare_pages_domain": {
+		"account": {"Pages Read"},
+	},
+	"cloudflare_registrar_domain": {
+		"account": {"Registrar: Domains Read"},
+	},
+	"cloudflare_list": {
+		"account": {"Account Filter Lists Read"},
+	},
+	"cloudflare_list_item": {
+		"account": {"Account Filter Lists Read"},
+	},
+	"cloudflare_stream": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_audio_track": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_key": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_live_input": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_watermark": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_webhook": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_caption_language": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_stream_download": {
+		"account": {"Stream Read"},
+	},
+	"cloudflare_notification_policy_webhooks": {
+		"account": {"Notifications Read"},
+	},
+	"cloudflare_notification_policy": {
+		"account": {"Notifications Read"},
+	},
+	"cloudflare_d1_database": {
+		"account": {"D1 Read"},
+	},
+	"cloudflare_r2_bucket": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_bucket_lifecycle": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_bucket_cors": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_custom_domain": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_managed_domain": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_bucket_event_notification": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_bucket_lock": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_r2_bucket_sippy": {
+		"account": {"Workers R2 Storage Read"},
+	},
+	"cloudflare_workers_for_platforms_dispatch_namespace": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_workers_for_platforms_script_secret": {
+		"account": {"Workers Scripts Read"},
+	},
+	"cloudflare_zero_trust_dex_test": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_managed_networks": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_default_profile": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_default_profile_local_domain_fallback": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_default_profile_certificates": {
+		"zone": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_custom_profile": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_custom_profile_local_domain_fallback": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_posture_rule": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_device_posture_integration": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_access_identity_provider": {
+		"account": {"Access: Organizations, Identity Providers, and Groups Read"},
+		"zone":    {"Access: Organizations, Identity Providers, and Groups Read"},
+	},
+	"cloudflare_zero_trust_organization": {
+		"account": {"Access: Organizations, Identity Providers, and Groups Read"},
+		"zone":    {"Access: Organizations, Identity Providers, and Groups Read"},
+	},
+	"cloudflare_zero_trust_access_infrastructure_target": {
+		"account": {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_access_application": {
+		"account": {"Access: Apps and Policies Read"},
+		"zone":    {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_access_short_lived_certificate": {
+		"account": {"Access: Apps and Policies Read"},
+		"zone":    {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_access_mtls_certificate": {
+		"account": {"Access: Mutual TLS Certificates Read"},
+		"zone":    {"Access: Mutual TLS Certificates Read"},
+	},
+	"cloudflare_zero_trust_access_mtls_hostname_settings": {
+		"account": {"Access: Mutual TLS Certificates Read"},
+		"zone":    {"Access: Mutual TLS Certificates Read"},
+	},
+	"cloudflare_zero_trust_access_group": {
+		"account": {"Access: Organizations, Identity Providers, and Groups Read"},
+		"zone":    {"Access: Organizations, Identity Providers, and Groups Read"},
+	},
+	"cloudflare_zero_trust_access_service_token": {
+		"account": {"Access: Service Tokens Read"},
+		"zone":    {"Access: Service Tokens Read"},
+	},
+	"cloudflare_zero_trust_access_key_configuration": {
+		"account": {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_access_custom_page": {
+		"account": {"Access: Custom Pages Read"},
+	},
+	"cloudflare_zero_trust_access_tag": {
+		"account": {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_access_policy": {
+		"account": {"Access: Apps and Policies Read"},
+	},
+	"cloudflare_zero_trust_tunnel_cloudflared": {
+		"account": {"Cloudflare Tunnel Read"},
+	},
+	"cloudflare_zero_trust_tunnel_cloudflared_config": {
+		"account": {"Cloudflare Tunnel Read"},
+	},
+	"cloudflare_zero_trust_dlp_dataset": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_dlp_custom_profile": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_dlp_predefined_profile": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_dlp_entry": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_categories": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_app_types": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_settings": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_list": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_dns_location": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_logging": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_proxy_endpoint": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_policy": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_gateway_certificate": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_tunnel_cloudflared_route": {
+		"account": {"Cloudflare Tunnel Read"},
+	},
+	"cloudflare_zero_trust_tunnel_cloudflared_virtual_network": {
+		"account": {"Cloudflare Tunnel Read"},
+	},
+	"cloudflare_zero_trust_risk_behavior": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_zero_trust_risk_scoring_integration": {
+		"account": {"Zero Trust Read"},
+	},
+	"cloudflare_turnstile_widget": {
+		"account": {"Turnstile Sites Read"},
+	},
+	"cloudflare_hyperdrive_config": {
+		"account": {"Hyperdrive Read"},
+	},
+	"cloudflare_web_analytics_site": {
+		"account": {"Account Analytics Read"},
+	},
+	"cloudflare_web_analytics_rule": {
+		"account": {"Account Analytics Read"},
+	},
+	"cloudflare_bot_management": {
+		"zone": {"Bot Management Read"},
+	},
+	"cloudflare_observatory_scheduled_test": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_hostname_tls_setting": {
+		"zone": {"Zone Settings Read"},
+	},
+	"cloudflare_snippets": {
+		"zone": {"Snippets Read"},
+	},
+	"cloudflare_snippet_rules": {
+		"zone": {"Snippets Read"},
+	},
+	"cloudflare_calls_sfu_app": {
+		"account": {"Calls Read"},
+	},
+	"cloudflare_calls_turn_app": {
+		"account": {"Calls Read"},
+	},
+	"cloudflare_cloudforce_one_request": {
+		"account": {"Cloudforce One Read"},
+	},
+	"cloudflare_cloudforce_one_request_message": {
+		"account": {"Cloudforce One Read"},
+	},
+	"cloudflare_cloudforce_one_request_priority": {
+		"account": {"Cloudforce One Read"},
+	},
+	"cloudflare_cloudforce_one_request_asset": {
+		"account": {"Cloudforce One Read"},
+	},
+	"cloudflare_leaked_credential_check": {
+		"zone": {"Zone WAF Read"},
+	},
+	"cloudflare_leaked_credential_check_rule": {
+		"zone": {"Zone WAF Read"},
+	},
+	"cloudflare_content_scanning": {
+		"zone": {"Zone WAF Read"},
+	},
+	"cloudflare_content_scanning_expression": {
+		"zone": {"Zone WAF Read"},
+	},
+	"cloudflare_custom_pages": {
+		"account": {"Account Custom Pages Read"},
+		"zone":    {"Custom Pages Read"},
+	},
+	"cloudflare_ai_gateway": {
+		"account": {"AI Gateway Read"},
+	},
+	"cloudflare_ai_search_namespace": {
+		"account": {"AI Search Read"},
+	},
+	"cloudflare_ai_search_instance": {
+		"account": {"AI Search Read"},
+	},
+	"cloudflare_ai_search_token": {
+		"account": {"AI Search Read"},
+	},
+}
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceToPermissionGroupsCoversEndpoints(t *testing.T) {
+	for _, rType := range ResourceTypes() {
+		if originCAKeyResources[rType] {
+			_, ok := resourceToPermissionGroups[rType]
+			assert.Falsef(t, ok, "%s needs the Origin CA key, not permission groups", rType)
+			continue
+		}
+		groups, ok := resourceToPermissionGroups[rType]
+		if assert.Truef(t, ok, "%s has no permission groups", rType) {
+			assert.NotEmptyf(t, groups, "%s has no permission groups", rType)
+		}
+	}
+	for rType := range resourceToPermissionGroups {
+		_, ok := Endpoint(rType)
+		assert.Truef(t, ok, "%s has permission groups but is not a known resource", rType)
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cfv0 "github.com/cloudflare/cloudflare-go"
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// runContext is the state of a single generate, import or snapshot run. It
+// is passed through fetching, transforming and rendering so that runs don't
+// share any state and can run concurrently, e.g. one per job or per test.
+type runContext struct {
+	// accountID or zoneID is the scope of the run.
+	accountID string
+	zoneID    string
+
+	credentials credentials
+	api         *cloudflare.Client
+	apiV0       *cfv0.API
+
+	// providerVersion is the version of the Cloudflare provider the run
+	// generates configuration or import commands for.
+	providerVersion string
+
+	// resourceIDs are the parent IDs, keyed by resource type, of resource
+	// types that are fetched per parent.
+	resourceIDs map[string][]string
+
+	// fromSnapshotDir is the directory API responses are read from instead
+	// of calling the API, and snapshotDir the one they are written to while
+	// a snapshot is taken.
+	fromSnapshotDir string
+	snapshotDir     string
+	cache           *apiCache
+
+	// filters are the attribute values, keyed by resource type, resources
+	// must have to be generated.
+	filters map[string]map[string]string
+
+	transformRules     map[string][]transformRule
+	transformers       map[string]string
+	transformerTimeout time.Duration
+
+	// stubDir is the directory files referenced by the generated
+	// configuration are created in, and stubFiles the files referenced so
+	// far relative to it.
+	stubDir   string
+	stubFiles []string
+
+	sensitiveVariables bool
+	secretsFile        string
+	pii                *piiRedactor
+	piiMappingFile     string
+
+	// log receives the diagnostics of the run.
+	log *logrus.Logger
+}
+
+// isV5 reports whether the run targets v5 of the provider.
+func (rc *runContext) isV5() bool {
+	return strings.HasPrefix(rc.providerVersion, "5")
+}
+
+// scopeIdentifier returns the cloudflare-go v0 identifier of the scope.
+func (rc *runContext) scopeIdentifier() *cfv0.ResourceContainer {
+	if rc.accountID != "" {
+		return cfv0.AccountIdentifier(rc.accountID)
+	}
+	return cfv0.ZoneIdentifier(rc.zoneID)
+}
+
+// parentIDs returns the IDs given for rType when it is fetched per parent,
+// or an error if it is requested without any.
+func (rc *runContext) parentIDs(resources []string, rType string) ([]string, error) {
+	if !isSupportedPathParam(resources, rType) {
+		return nil, nil
+	}
+	ids, ok := rc.resourceIDs[rType]
+	if !ok || len(ids) == 0 {
+		return nil, fmt.Errorf("no resource IDs defined for resource %s", rType)
+	}
+	return ids, nil
+}
@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var invalidVariableNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// sensitiveVariable is a variable standing in for a sensitive attribute.
+type sensitiveVariable struct {
+	name string
+	ty   cty.Type
+}
+
+// sensitiveVariables collects the variables used in place of sensitive
+// attributes while a resource type is being generated, along with the values
+// returned by the API for them.
+type sensitiveVariables struct {
+	variables []sensitiveVariable
+	values    map[string]interface{}
+	log       *logrus.Logger
+}
+
+func newSensitiveVariables(log *logrus.Logger) *sensitiveVariables {
+	return &sensitiveVariables{values: map[string]interface{}{}, log: log}
+}
+
+// sensitiveVariableName returns the name of the variable for attrName of the
+// resource, e.g. `cloudflare_workers_secret_terraform_managed_resource_0_text`.
+func sensitiveVariableName(resourceType, resourceName, attrName string) string {
+	return invalidVariableNameChars.ReplaceAllString(resourceType+"_"+resourceName+"_"+attrName, "_")
+}
+
+// replace writes a reference to a variable for attrName instead of its value.
+// Attributes without a value are only referenced when they are required, as
+// the configuration is otherwise invalid.
+func (s *sensitiveVariables) replace(body *hclwrite.Body, resourceType, resourceName, attrName string, attr *tfjson.SchemaAttribute, value interface{}) {
+	if (value == nil || value == "") && !attr.Required {
+		return
+	}
+	// values the API doesn't return are already read from a file.
+	if ref, ok := value.(fileReference); ok {
+		body.SetAttributeRaw(attrName, ref.tokens())
+		return
+	}
+	body.SetAttributeRaw(attrName, s.reference(resourceType, resourceName, attrName, attr, value).tokens())
+}
+
+// replaceNested replaces the values of sensitive attributes nested in the
+// attributes and blocks of a resource with variable references, which are
+// written in place of the values along with the rest of the nested object.
+// Top level attributes are left to replace.
+func (s *sensitiveVariables) replaceNested(resourceType, resourceName string, block *tfjson.SchemaBlock, data map[string]interface{}) {
+	for _, name := range slices.Sorted(maps.Keys(block.Attributes)) {
+		attr := block.Attributes[name]
+		if attr.AttributeNestedType != nil && !attr.Sensitive {
+			s.replaceIn(resourceType, resourceName, name, attr.AttributeNestedType.NestingMode, attr.AttributeNestedType.Attributes, nil, data[name])
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(block.NestedBlocks)) {
+		nested := block.NestedBlocks[name]
+		s.replaceIn(resourceType, resourceName, name, nested.NestingMode, nested.Block.Attributes, nested.Block.NestedBlocks, data[name])
+	}
+}
+
+// replaceIn replaces the sensitive attributes in value, the objects nested at
+// path with the given nesting mode, attributes and blocks.
+func (s *sensitiveVariables) replaceIn(resourceType, resourceName, path string, mode tfjson.SchemaNestingMode, attrs map[string]*tfjson.SchemaAttribute, blocks map[string]*tfjson.SchemaBlockType, value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			s.replaceIn(resourceType, resourceName, fmt.Sprintf("%s.%d", path, i), mode, attrs, blocks, item)
+		}
+	case []map[string]interface{}:
+		for i, item := range v {
+			s.replaceIn(resourceType, resourceName, fmt.Sprintf("%s.%d", path, i), mode, attrs, blocks, item)
+		}
+	case map[string]interface{}:
+		if mode == tfjson.SchemaNestingModeMap {
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				s.replaceIn(resourceType, resourceName, path+"."+key, tfjson.SchemaNestingModeSingle, attrs, blocks, v[key])
+			}
+			return
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(attrs)) {
+			attr, attrPath := attrs[name], path+"."+name
+			switch {
+			case attr.Sensitive:
+				if (v[name] == nil || v[name] == "") && !attr.Required {
+					continue
+				}
+				if _, ok := v[name].(fileReference); ok {
+					continue
+				}
+				v[name] = s.reference(resourceType, resourceName, attrPath, attr, v[name])
+			case attr.AttributeNestedType != nil:
+				s.replaceIn(resourceType, resourceName, attrPath, attr.AttributeNestedType.NestingMode, attr.AttributeNestedType.Attributes, nil, v[name])
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(blocks)) {
+			s.replaceIn(resourceType, resourceName, path+"."+name, blocks[name].NestingMode, blocks[name].Block.Attributes, blocks[name].Block.NestedBlocks, v[name])
+		}
+	}
+}
+
+// reference returns a reference to the variable standing in for attrName of
+// the resource and records value as the value of the variable.
+func (s *sensitiveVariables) reference(resourceType, resourceName, attrName string, attr *tfjson.SchemaAttribute, value interface{}) variableReference {
+	name := sensitiveVariableName(resourceType, resourceName, attrName)
+	s.variables = append(s.variables, sensitiveVariable{name: name, ty: attr.AttributeType})
+	if value != nil && value != "" {
+		s.values[name] = value
+	}
+	return variableReference{name: name}
+}
+
+// declare appends a `sensitive = true` variable block for every variable that
+// has been referenced.
+func (s *sensitiveVariables) declare(body *hclwrite.Body) {
+	for _, v := range s.variables {
+		block := body.AppendNewBlock("variable", []string{v.name}).Body()
+		// nested attributes have no type in the schema and are left as `any`.
+		if v.ty != cty.NilType {
+			block.SetAttributeRaw("type", hclwrite.Tokens{
+				{Type: hclsyntax.TokenIdent, Bytes: []byte(typeexpr.TypeString(v.ty))},
+			})
+		}
+		block.SetAttributeValue("sensitive", cty.True)
+		body.AppendNewline()
+	}
+}
+
+// writeSecrets merges the known values into the tfvars JSON file at path so
+// that generating several resource types accumulates all of them.
+func (s *sensitiveVariables) writeSecrets(path string) error {
+	return mergeJSONFile(s.log, path, s.values)
+}
+
+// mergeJSONFile merges values into the JSON object stored at path, creating it
+// with permissions restricted to the current user. Nothing is written when
+// there are no values.
+func mergeJSONFile(log *logrus.Logger, path string, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	merged := map[string]interface{}{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &merged); err != nil {
+			return fmt.Errorf("failed to parse existing file %s: %w", path, err)
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for name, value := range values {
+		merged[name] = value
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log.WithField("keys", names).Debugf("writing values to %s", path)
+
+	out, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), 0600)
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestSensitiveVariables(t *testing.T) {
+	s := newSensitiveVariables(logrus.New())
+
+	f := hclwrite.NewEmptyFile()
+	resource := f.Body().AppendNewBlock("resource", []string{"cloudflare_workers_secret", "terraform_managed_resource_0"}).Body()
+	s.replace(resource, "cloudflare_workers_secret", "terraform_managed_resource_0", "text", &tfjson.SchemaAttribute{AttributeType: cty.String, Required: true, Sensitive: true}, "s3cr3t")
+	s.replace(resource, "cloudflare_workers_secret", "terraform_managed_resource_0", "optional", &tfjson.SchemaAttribute{AttributeType: cty.String, Optional: true, Sensitive: true}, nil)
+	s.replace(resource, "cloudflare_workers_secret", "terraform_managed_resource_0", "keys", &tfjson.SchemaAttribute{AttributeType: cty.List(cty.String), Required: true, Sensitive: true}, nil)
+	f.Body().AppendNewline()
+	s.declare(f.Body())
+
+	assert.Equal(t, `resource "cloudflare_workers_secret" "terraform_managed_resource_0" {
+  text = var.cloudflare_workers_secret_terraform_managed_resource_0_text
+  keys = var.cloudflare_workers_secret_terraform_managed_resource_0_keys
+}
+
+variable "cloudflare_workers_secret_terraform_managed_resource_0_text" {
+  type      = string
+  sensitive = true
+}
+
+variable "cloudflare_workers_secret_terraform_managed_resource_0_keys" {
+  type      = list(string)
+  sensitive = true
+}
+
+`, string(hclwrite.Format(f.Bytes())))
+
+	path := filepath.Join(t.TempDir(), "secrets.auto.tfvars.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"existing": "value"}`), 0600))
+	assert.NoError(t, s.writeSecrets(path))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"existing": "value", "cloudflare_workers_secret_terraform_managed_resource_0_text": "s3cr3t"}`, string(data))
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestSensitiveVariablesNested(t *testing.T) {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"name": {AttributeType: cty.String, Required: true},
+			"config": {Optional: true, AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+				NestingMode: tfjson.SchemaNestingModeSingle,
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"user":     {AttributeType: cty.String, Optional: true},
+					"password": {AttributeType: cty.String, Optional: true, Sensitive: true},
+				},
+			}},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"credentials": {NestingMode: tfjson.SchemaNestingModeList, Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"secret": {AttributeType: cty.String, Required: true, Sensitive: true},
+				},
+			}},
+		},
+	}
+	data := map[string]interface{}{
+		"name":        "example",
+		"config":      map[string]interface{}{"user": "admin", "password": "hunter2"},
+		"credentials": []interface{}{map[string]interface{}{"secret": "s3cr3t"}, map[string]interface{}{}},
+	}
+
+	s := newSensitiveVariables(logrus.New())
+	f := hclwrite.NewEmptyFile()
+	resource := f.Body().AppendNewBlock("resource", []string{"cloudflare_example", "terraform_managed_resource_0"}).Body()
+	s.replaceNested("cloudflare_example", "terraform_managed_resource_0", block, data)
+	(&runContext{log: logrus.New()}).processBlocks(block, data, resource, "")
+	f.Body().AppendNewline()
+	s.declare(f.Body())
+
+	// the second credential has no secret but needs one to be valid.
+	assert.Equal(t, `resource "cloudflare_example" "terraform_managed_resource_0" {
+  config = {
+    password = var.cloudflare_example_terraform_managed_resource_0_config_password
+    user     = "admin"
+  }
+  credentials {
+    secret = var.cloudflare_example_terraform_managed_resource_0_credentials_0_secret
+  }
+  credentials {
+    secret = var.cloudflare_example_terraform_managed_resource_0_credentials_1_secret
+  }
+  name = "example"
+}
+
+variable "cloudflare_example_terraform_managed_resource_0_config_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "cloudflare_example_terraform_managed_resource_0_credentials_0_secret" {
+  type      = string
+  sensitive = true
+}
+
+variable "cloudflare_example_terraform_managed_resource_0_credentials_1_secret" {
+  type      = string
+  sensitive = true
+}
+
+`, string(hclwrite.Format(f.Bytes())))
+	assert.Equal(t, map[string]interface{}{
+		"cloudflare_example_terraform_managed_resource_0_config_password":      "hunter2",
+		"cloudflare_example_terraform_managed_resource_0_credentials_0_secret": "s3cr3t",
+	}, s.values)
+}
+
+func TestSensitiveVariableName(t *testing.T) {
+	assert.Equal(t, "cloudflare_dns_record_terraform_managed_resource_1_000000_0_content", sensitiveVariableName("cloudflare_dns_record", "terraform_managed_resource_1.000000_0", "content"))
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// snapshotPrefixLength limits the readable part of snapshot file names.
+const snapshotPrefixLength = 100
+
+var snapshotEndpointKey = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// snapshotEntry is the on-disk representation of a single API response. Only
+// the `result` and the `result_info` (needed to replay pagination) are kept.
+type snapshotEntry struct {
+	Endpoint   string          `json:"endpoint"`
+	Result     json.RawMessage `json:"result"`
+	ResultInfo json.RawMessage `json:"result_info,omitempty"`
+}
+
+// snapshotPath returns the location of the snapshot file for an endpoint. The
+// file is named after a hash of the endpoint, as distinct endpoints such as
+// `/a/b` and `/a_b` share the same readable prefix.
+func snapshotPath(dir, rType, endpoint string) string {
+	prefix := strings.Trim(snapshotEndpointKey.ReplaceAllString(endpoint, "_"), "_")
+	if len(prefix) > snapshotPrefixLength {
+		prefix = prefix[:snapshotPrefixLength]
+	}
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(dir, rType, prefix+"-"+hex.EncodeToString(sum[:16])+".json")
+}
+
+// writeSnapshot stores the `result` and `result_info` of an API response body.
+func writeSnapshot(dir, rType, endpoint string, body []byte) error {
+	entry := snapshotEntry{Endpoint: endpoint}
+	if r := gjson.GetBytes(body, "result"); r.Exists() {
+		entry.Result = json.RawMessage(r.Raw)
+	} else {
+		entry.Result = json.RawMessage("null")
+	}
+	if ri := gjson.GetBytes(body, "result_info"); ri.Exists() {
+		entry.ResultInfo = json.RawMessage(ri.Raw)
+	}
+
+	b, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := snapshotPath(dir, rType, endpoint)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// readSnapshot returns a stored API response in the same shape as the body
+// returned by the API. A missing endpoint results in an error that satisfies
+// `errors.Is(err, fs.ErrNotExist)`.
+func readSnapshot(dir, rType, endpoint string) ([]byte, error) {
+	b, err := os.ReadFile(snapshotPath(dir, rType, endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("endpoint %s not found in snapshot: %w", endpoint, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v4"
+	"github.com/cloudflare/cloudflare-go/v4/option"
+
+	"github.com/dnaeon/go-vcr/recorder"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	r, err := recorder.New("../../../../testdata/cloudflare/v5/cloudflare_list_item_cursor_pagination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := r.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	dir := t.TempDir()
+	endpoint := "/accounts/" + cloudflareTestAccountID + "/rules/lists/2a4b8b2017aa4b3cb9e1151b52c81d22/items"
+	var result *http.Response
+
+	rc := &runContext{
+		api:         cloudflare.NewClient(option.WithHTTPClient(&http.Client{Transport: r})),
+		snapshotDir: dir,
+		log:         logrus.New(),
+	}
+	live, err := rc.getAPIResponse(context.Background(), result, "cloudflare_list_item", nil, endpoint)
+	assert.NoError(t, err)
+	assert.FileExists(t, snapshotPath(dir, "cloudflare_list_item", endpoint))
+	assert.FileExists(t, snapshotPath(dir, "cloudflare_list_item", endpoint+"?cursor=yyy"))
+
+	// replaying the snapshot must not touch the API.
+	rc = &runContext{fromSnapshotDir: dir, log: logrus.New()}
+	replayed, err := rc.getAPIResponse(context.Background(), result, "cloudflare_list_item", nil, endpoint)
+	assert.NoError(t, err)
+	assert.Equal(t, live, replayed)
+
+	_, err = rc.getAPIResponse(context.Background(), result, "cloudflare_list_item", nil, "/accounts/"+cloudflareTestAccountID+"/rules/lists")
+	assert.True(t, isNotFound(err), "missing endpoints should be treated as not found")
+}
+
+func TestSnapshotPath(t *testing.T) {
+	endpoints := []string{"/a/b", "/a_b", "/a/b?cursor=x", "/a/b?cursor=y"}
+	paths := map[string]bool{}
+	for _, endpoint := range endpoints {
+		paths[snapshotPath("dir", "cloudflare_list", endpoint)] = true
+	}
+	assert.Len(t, paths, len(endpoints), "distinct endpoints must not share a snapshot file")
+	assert.Equal(t, snapshotPath("dir", "cloudflare_list", "/a/b"), snapshotPath("dir", "cloudflare_list", "/a/b"))
+}
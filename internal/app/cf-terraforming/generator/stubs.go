@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// fileReference is written as a call to function with a path relative to the
+// module instead of a literal value. It is used for values, such as private
+// keys, that the API never returns.
+type fileReference struct {
+	function string
+	path     string
+	stub     string
+}
+
+// tokens returns the HCL expression for the reference, e.g.
+// `file("${path.module}/certs/example.pem")`.
+func (f fileReference) tokens() hclwrite.Tokens {
+	path := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("path")},
+		{Type: hclsyntax.TokenDot, Bytes: []byte(".")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("module")},
+		{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")},
+		{Type: hclsyntax.TokenQuotedLit, Bytes: []byte("/" + f.path)},
+		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+	}
+	return hclwrite.TokensForFunctionCall(f.function, path)
+}
+
+// expression returns the reference as it is written in the configuration.
+func (f fileReference) expression() string {
+	return string(f.tokens().Bytes())
+}
+
+// MarshalJSON encodes the reference as its expression, e.g. when the resource
+// is passed to a transformer.
+func (f fileReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.expression())
+}
+
+// addFileReference sets key on every resource to a reference to a file in dir
+// named after the resource type and its identifier.
+func addFileReference(response *[]interface{}, resourceCount int, rType, key, function, dir, ext, stub string) {
+	for i := 0; i < resourceCount; i++ {
+		item := (*response)[i].(map[string]interface{})
+
+		name := fmt.Sprintf("%s_%d", rType, i)
+		for _, idKey := range []string{"id", "uid"} {
+			if id, ok := item[idKey].(string); ok && id != "" {
+				name = rType + "_" + id
+				break
+			}
+		}
+
+		item[key] = fileReference{
+			function: function,
+			path:     filepath.ToSlash(filepath.Join(dir, name+ext)),
+			stub:     stub,
+		}
+	}
+}
+
+// createStubFiles records the files referenced by the attributes of a
+// resource and creates a stub for those that don't exist yet.
+func (rc *runContext) createStubFiles(structData map[string]interface{}) {
+	keys := make([]string, 0, len(structData))
+	for key := range structData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		ref, ok := structData[key].(fileReference)
+		if !ok {
+			continue
+		}
+		rc.stubFiles = append(rc.stubFiles, ref.path)
+
+		path := filepath.Join(rc.stubDir, filepath.FromSlash(ref.path))
+		if _, err := os.Stat(path); err == nil || !errors.Is(err, fs.ErrNotExist) {
+			// never overwrite a file that has already been filled in.
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			rc.log.Warnf("failed to create directory for %s: %s", path, err)
+			continue
+		}
+		if err := os.WriteFile(path, []byte(ref.stub), 0600); err != nil {
+			rc.log.Warnf("failed to create stub file %s: %s", path, err)
+		}
+	}
+}
+
+// logStubFiles lists the files that must be filled in before the generated
+// configuration can be applied.
+func (rc *runContext) logStubFiles() {
+	if len(rc.stubFiles) == 0 {
+		return
+	}
+	sort.Strings(rc.stubFiles)
+	rc.log.Warnf("the generated configuration references files that must be filled in before applying:")
+	for _, f := range rc.stubFiles {
+		rc.log.Warnf("  %s", filepath.Join(rc.stubDir, filepath.FromSlash(f)))
+	}
+	rc.stubFiles = nil
+}
+
+// Contents of the stub files created for PEM encoded values. They are not valid
+// PEM so that applying without replacing them fails rather than uploading an
+// empty value.
+const (
+	pemCertificateStub = "# Replace this file with the PEM encoded certificate.\n"
+	pemPrivateKeyStub  = "# Replace this file with the PEM encoded private key.\n"
+)
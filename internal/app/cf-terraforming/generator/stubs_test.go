@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileReference(t *testing.T) {
+	rc := &runContext{stubDir: t.TempDir(), log: logrus.New()}
+
+	response := []interface{}{
+		map[string]interface{}{"id": "abc"},
+		map[string]interface{}{},
+	}
+	addFileReference(&response, len(response), "cloudflare_keyless_certificate", "certificate", "file", "certs", ".pem", pemCertificateStub)
+
+	// an existing file must not be overwritten.
+	assert.NoError(t, os.MkdirAll(filepath.Join(rc.stubDir, "certs"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(rc.stubDir, "certs", "cloudflare_keyless_certificate_1.pem"), []byte("existing"), 0600))
+
+	f := hclwrite.NewEmptyFile()
+	for i, item := range response {
+		rc.createStubFiles(item.(map[string]interface{}))
+		body := f.Body().AppendNewBlock("resource", []string{"cloudflare_keyless_certificate", fmt.Sprintf("r%d", i)}).Body()
+		rc.writeAttrLine("certificate", item.(map[string]interface{})["certificate"], "", body)
+	}
+
+	assert.Equal(t, `resource "cloudflare_keyless_certificate" "r0" {
+  certificate = file("${path.module}/certs/cloudflare_keyless_certificate_abc.pem")
+}
+resource "cloudflare_keyless_certificate" "r1" {
+  certificate = file("${path.module}/certs/cloudflare_keyless_certificate_1.pem")
+}
+`, string(hclwrite.Format(f.Bytes())))
+
+	assert.Equal(t, []string{"certs/cloudflare_keyless_certificate_abc.pem", "certs/cloudflare_keyless_certificate_1.pem"}, rc.stubFiles)
+
+	data, err := os.ReadFile(filepath.Join(rc.stubDir, "certs", "cloudflare_keyless_certificate_abc.pem"))
+	assert.NoError(t, err)
+	assert.Equal(t, pemCertificateStub, string(data))
+
+	data, err = os.ReadFile(filepath.Join(rc.stubDir, "certs", "cloudflare_keyless_certificate_1.pem"))
+	assert.NoError(t, err)
+	assert.Equal(t, "existing", string(data))
+}
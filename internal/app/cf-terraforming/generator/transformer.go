@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTransformerTimeout bounds how long a single transformer may run for
+// when Options.TransformerTimeout isn't set.
+const DefaultTransformerTimeout = 30 * time.Second
+
+// applyTransformer runs the transformer of the run for rType, if any, on the
+// resources. The transformer receives the resources as a JSON array on stdin
+// and must print the modified array on stdout. The resource type is passed in
+// the `CF_TERRAFORMING_RESOURCE_TYPE` environment variable.
+func (rc *runContext) applyTransformer(ctx context.Context, rType string, resources []interface{}) ([]interface{}, error) {
+	executable, ok := rc.transformers[rType]
+	if !ok {
+		return resources, nil
+	}
+
+	// file references are passed as their expression and restored when the
+	// transformer returns them unchanged.
+	references := map[string]fileReference{}
+	for _, item := range resources {
+		if resource, ok := item.(map[string]interface{}); ok {
+			for _, value := range resource {
+				if ref, ok := value.(fileReference); ok {
+					references[ref.expression()] = ref
+				}
+			}
+		}
+	}
+
+	input, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s for transformer %s: %w", rType, executable, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rc.transformerTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, executable)
+	c.Env = append(os.Environ(), "CF_TERRAFORMING_RESOURCE_TYPE="+rType)
+	c.Stdin = bytes.NewReader(input)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	// don't wait for processes started by the transformer that keep its
+	// output open once it has been killed.
+	c.WaitDelay = time.Second
+
+	rc.log.WithField("resource", rType).Debugf("running transformer %s", executable)
+	if err := c.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("transformer %s for %s timed out after %s", executable, rType, rc.transformerTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("transformer %s for %s failed: %w: %s", executable, rType, err, msg)
+		}
+		return nil, fmt.Errorf("transformer %s for %s failed: %w", executable, rType, err)
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		rc.log.WithField("resource", rType).Debugf("transformer %s: %s", executable, msg)
+	}
+
+	var output []interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("transformer %s for %s must print a JSON array of objects: %w", executable, rType, err)
+	}
+	for i, item := range output {
+		resource, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("transformer %s for %s must print a JSON array of objects, item %d is %T", executable, rType, i, item)
+		}
+		for key, value := range resource {
+			if s, ok := value.(string); ok {
+				if ref, ok := references[s]; ok {
+					resource[key] = ref
+				}
+			}
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTransformer(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("transformer scripts require a POSIX shell")
+	}
+	path := filepath.Join(t.TempDir(), "transformer")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0700))
+	return path
+}
+
+func TestApplyTransformer(t *testing.T) {
+	rc := &runContext{transformerTimeout: 5 * time.Second, log: logrus.New()}
+
+	ref := fileReference{function: "file", path: "certs/cloudflare_keyless_certificate_1.pem"}
+	resources := []interface{}{
+		map[string]interface{}{"id": "1", "certificate": ref},
+	}
+
+	t.Run("no transformer", func(t *testing.T) {
+		rc.transformers = map[string]string{}
+		got, err := rc.applyTransformer(context.Background(), "cloudflare_keyless_certificate", resources)
+		require.NoError(t, err)
+		assert.Equal(t, resources, got)
+	})
+
+	t.Run("rewrites resources", func(t *testing.T) {
+		// the resource type is available to the transformer and file
+		// references that are passed through are kept.
+		rc.transformers = map[string]string{"cloudflare_keyless_certificate": writeTransformer(t,
+			`sed "s/\"id\":\"1\"/\"id\":\"1\",\"type\":\"$CF_TERRAFORMING_RESOURCE_TYPE\"/"`)}
+		got, err := rc.applyTransformer(context.Background(), "cloudflare_keyless_certificate", resources)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"id": "1", "type": "cloudflare_keyless_certificate", "certificate": ref},
+		}, got)
+	})
+
+	tests := map[string]struct {
+		script string
+		err    string
+	}{
+		"failure":     {"echo 'unexpected input' >&2; exit 3", "failed: exit status 3: unexpected input"},
+		"invalid":     {"echo 'not json'", "must print a JSON array of objects"},
+		"not objects": {`echo '["a"]'`, "item 0 is string"},
+		"timeout":     {"exec sleep 5", "timed out after 100ms"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rc.transformerTimeout = 100 * time.Millisecond
+			rc.transformers = map[string]string{"cloudflare_keyless_certificate": writeTransformer(t, "cat >/dev/null; "+tc.script)}
+			_, err := rc.applyTransformer(context.Background(), "cloudflare_keyless_certificate", resources)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
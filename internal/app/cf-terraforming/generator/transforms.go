@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed transforms.yaml
+var defaultTransformsYAML []byte
+
+// transformRule is a single operation on the resources of a response. Exactly
+// one of the fields is set.
+type transformRule struct {
+	Remap     *remapRule             `yaml:"remap"`
+	Set       map[string]interface{} `yaml:"set"`
+	SetParent string                 `yaml:"set_parent"`
+	Delete    []string               `yaml:"delete"`
+	Denest    string                 `yaml:"denest"`
+	Wrap      *wrapRule              `yaml:"wrap"`
+}
+
+type remapRule struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+type wrapRule struct {
+	Attribute string `yaml:"attribute"`
+	Parent    string `yaml:"parent"`
+}
+
+// validate checks that the rule sets exactly one operation with the values it
+// needs.
+func (r transformRule) validate() error {
+	set := 0
+	for _, ok := range []bool{r.Remap != nil, r.Set != nil, r.SetParent != "", r.Delete != nil, r.Denest != "", r.Wrap != nil} {
+		if ok {
+			set++
+		}
+	}
+	switch {
+	case set != 1:
+		return fmt.Errorf("each rule must set exactly one of remap, set, set_parent, delete, denest or wrap")
+	case r.Remap != nil && (r.Remap.From == "" || r.Remap.To == ""):
+		return errors.New("remap requires both from and to")
+	case r.Wrap != nil && r.Wrap.Attribute == "":
+		return errors.New("wrap requires an attribute")
+	}
+	return nil
+}
+
+// apply runs the rule on the resources fetched for parent.
+func (r transformRule) apply(response *[]interface{}, parent string) {
+	switch {
+	case r.Remap != nil:
+		remapProperty(response, len(*response), r.Remap.From, r.Remap.To)
+	case r.Set != nil:
+		for key, value := range r.Set {
+			for _, item := range *response {
+				if resource, ok := item.(map[string]interface{}); ok {
+					resource[key] = value
+				}
+			}
+		}
+	case r.SetParent != "":
+		addAttributeKeyValue(response, len(*response), r.SetParent, parent)
+	case r.Delete != nil:
+		for _, path := range r.Delete {
+			for _, item := range *response {
+				deletePath(item, strings.Split(path, "."))
+			}
+		}
+	case r.Denest != "":
+		denestResponses(response, len(*response), r.Denest)
+	case r.Wrap != nil:
+		wrapped := map[string]interface{}{r.Wrap.Attribute: *response}
+		if r.Wrap.Parent != "" {
+			wrapped[r.Wrap.Parent] = parent
+		}
+		*response = []interface{}{wrapped}
+	}
+}
+
+// parseTransformRules reads rules keyed by resource type from r.
+func parseTransformRules(r io.Reader) (map[string][]transformRule, error) {
+	rules := map[string][]transformRule{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for rType, typeRules := range rules {
+		for i, rule := range typeRules {
+			if err := rule.validate(); err != nil {
+				return nil, fmt.Errorf("rule %d of %s: %w", i+1, rType, err)
+			}
+		}
+	}
+	return rules, nil
+}
+
+func mustParseTransformRules(data []byte) map[string][]transformRule {
+	rules, err := parseTransformRules(bytes.NewReader(data))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in transform rules: %s", err))
+	}
+	return rules
+}
+
+// loadTransformRules returns the built-in rules with the rules for every
+// resource type in the file at path replacing the built-in ones.
+func loadTransformRules(path string, log *logrus.Logger) (map[string][]transformRule, error) {
+	rules := mustParseTransformRules(defaultTransformsYAML)
+	if path == "" {
+		return rules, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transforms file: %w", err)
+	}
+	defer f.Close()
+
+	overrides, err := parseTransformRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transforms file %s: %w", path, err)
+	}
+	for rType, typeRules := range overrides {
+		log.WithField("resource", rType).Debugf("using transform rules from %s", path)
+		rules[rType] = typeRules
+	}
+	return rules, nil
+}
+
+// transformResources reshapes a page of resources of rType fetched for parent
+// using its handler followed by the transform rules of the run, which start
+// out as the built-in rules and are replaced per resource type by those in the
+// file given with `--transforms`.
+func (rc *runContext) transformResources(rType string, response *[]interface{}, parent string) {
+	resourceHandlerFor(rType).Transform(rc, rType, response, parent)
+	for _, rule := range rc.transformRules[rType] {
+		rule.apply(response, parent)
+	}
+}
+
+// deletePath removes the attribute at the end of path from value, traversing
+// lists element by element.
+func deletePath(value interface{}, path []string) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			deletePath(item, path)
+		}
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		if child, ok := v[path[0]]; ok {
+			deletePath(child, path[1:])
+		}
+	}
+}
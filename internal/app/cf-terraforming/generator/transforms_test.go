@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformResources(t *testing.T) {
+	tests := map[string]struct {
+		parent   string
+		response []interface{}
+		want     []interface{}
+	}{
+		"cloudflare_dns_record": {
+			response: []interface{}{map[string]interface{}{"id": "1", "content": "x", "data": map[string]interface{}{}}},
+			want:     []interface{}{map[string]interface{}{"id": "1", "data": map[string]interface{}{}}},
+		},
+		"cloudflare_zone_setting": {
+			parent:   "always_online",
+			response: []interface{}{map[string]interface{}{"id": "always_online", "value": "on"}},
+			want:     []interface{}{map[string]interface{}{"id": "always_online", "setting_id": "always_online", "value": "on"}},
+		},
+		"cloudflare_pages_domain": {
+			parent:   "site",
+			response: []interface{}{map[string]interface{}{"name": "example.com"}},
+			want:     []interface{}{map[string]interface{}{"name": "example.com", "project_name": "site"}},
+		},
+		"cloudflare_waiting_room_rules": {
+			parent:   "room",
+			response: []interface{}{map[string]interface{}{"id": "1"}},
+			want: []interface{}{map[string]interface{}{
+				"waiting_room_id": "room",
+				"rules":           []interface{}{map[string]interface{}{"id": "1"}},
+			}},
+		},
+		"cloudflare_workers_deployment": {
+			parent:   "script",
+			response: []interface{}{map[string]interface{}{"deployments": []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}}}},
+			want: []interface{}{
+				map[string]interface{}{"id": "1", "script_name": "script"},
+				map[string]interface{}{"id": "2", "script_name": "script"},
+			},
+		},
+		"cloudflare_r2_bucket": {
+			response: []interface{}{map[string]interface{}{"buckets": []interface{}{map[string]interface{}{"name": "a"}}}},
+			want:     []interface{}{map[string]interface{}{"name": "a"}},
+		},
+		"cloudflare_waiting_room": {
+			response: []interface{}{map[string]interface{}{"id": "1"}},
+			want:     []interface{}{map[string]interface{}{"id": "1"}},
+		},
+	}
+
+	rc := &runContext{transformRules: mustParseTransformRules(defaultTransformsYAML), log: logrus.New()}
+	for rType, tc := range tests {
+		t.Run(rType, func(t *testing.T) {
+			rc.transformResources(rType, &tc.response, tc.parent)
+			assert.Equal(t, tc.want, tc.response)
+		})
+	}
+}
+
+func TestParseTransformRules(t *testing.T) {
+	rules, err := parseTransformRules(strings.NewReader(`
+cloudflare_dns_record:
+  - delete: [meta.auto_added, settings.flatten_cname]
+  - set: {proxied: false}
+`))
+	require.NoError(t, err)
+
+	response := []interface{}{map[string]interface{}{
+		"proxied":  true,
+		"meta":     map[string]interface{}{"auto_added": false, "source": "api"},
+		"settings": []interface{}{map[string]interface{}{"flatten_cname": true, "ipv4_only": true}},
+	}}
+	for _, rule := range rules["cloudflare_dns_record"] {
+		rule.apply(&response, "")
+	}
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"proxied":  false,
+		"meta":     map[string]interface{}{"source": "api"},
+		"settings": []interface{}{map[string]interface{}{"ipv4_only": true}},
+	}}, response)
+
+	tests := map[string]string{
+		"no operation":       "cloudflare_dns_record:\n  - {}\n",
+		"two operations":     "cloudflare_dns_record:\n  - {denest: a, set_parent: b}\n",
+		"incomplete remap":   "cloudflare_dns_record:\n  - remap: {from: a}\n",
+		"unknown operation":  "cloudflare_dns_record:\n  - rename: {from: a, to: b}\n",
+		"wrap without attrs": "cloudflare_dns_record:\n  - wrap: {parent: a}\n",
+	}
+	for name, rules := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseTransformRules(strings.NewReader(rules))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadTransformRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transforms.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+cloudflare_zone_setting:
+  - remap: {from: id, to: setting_id}
+  - delete: [editable]
+`), 0600))
+
+	rules, err := loadTransformRules(path, logrus.New())
+	require.NoError(t, err)
+	assert.Len(t, rules["cloudflare_zone_setting"], 2)
+	// resource types that aren't overridden keep the built-in rules.
+	assert.Equal(t, mustParseTransformRules(defaultTransformsYAML)["cloudflare_r2_bucket"], rules["cloudflare_r2_bucket"])
+
+	_, err = loadTransformRules(filepath.Join(t.TempDir(), "missing.yaml"), logrus.New())
+	assert.ErrorContains(t, err, "failed to read transforms file")
+}
@@ -0,0 +1,101 @@
+// Command endpointmapping generates resource_to_endpoint_mapping.go, which maps
+// every resource type of the v5 provider to its API endpoints, from the
+// Stainless configuration of the Cloudflare API and, optionally, its OpenAPI
+// specification.
+//
+// It is run with `go generate` in internal/app/cf-terraforming/generator:
+//
+//	STAINLESS_CONFIG=openapi.stainless.yml OPENAPI_SPEC=openapi.json go generate ./internal/app/cf-terraforming/generator
+//
+// The Stainless configuration provides the resource types and their `list` and
+// `get` operations. The OpenAPI specification provides the pagination style
+// of `list` operations that don't name one in the configuration and the field
+// of the API response that identifies a resource.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+)
+
+func main() {
+	configPath := flag.String("config", "", "Path to the Stainless configuration of the Cloudflare API")
+	specPath := flag.String("openapi", "", "Path to the OpenAPI specification of the Cloudflare API, in JSON or YAML (optional)")
+	output := flag.String("output", "resource_to_endpoint_mapping.go", "Path of the Go file to write")
+	pkg := flag.String("package", "generator", "Package of the Go file to write")
+	flag.Parse()
+
+	if err := run(*configPath, *specPath, *output, *pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "endpointmapping: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, specPath, output, pkg string) error {
+	if configPath == "" {
+		return fmt.Errorf("-config is required, set STAINLESS_CONFIG when running go generate")
+	}
+	config, err := readStainlessConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	var spec *openAPISpec
+	if specPath != "" {
+		if spec, err = readOpenAPISpec(specPath); err != nil {
+			return err
+		}
+	}
+
+	src, err := render(pkg, buildMappings(config, spec))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(output, src, 0644)
+}
+
+// render returns the formatted Go source declaring resourceToEndpoint with
+// mappings.
+func render(pkg string, mappings []mapping) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/tools/endpointmapping. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	buf.WriteString("var resourceToEndpoint = map[string]resourceEndpoint{\n")
+	for _, m := range mappings {
+		fmt.Fprintf(&buf, "%q: {\n", m.name)
+		fmt.Fprintf(&buf, "list: %q,\n", m.list)
+		fmt.Fprintf(&buf, "get: %q,\n", m.get)
+		if c, ok := scopeConstants[m.scope]; ok {
+			fmt.Fprintf(&buf, "scope: %s,\n", c)
+		}
+		if m.idField != "" {
+			fmt.Fprintf(&buf, "idField: %q,\n", m.idField)
+		}
+		if m.importID != "" {
+			fmt.Fprintf(&buf, "importID: %q,\n", m.importID)
+		}
+		if c, ok := paginationConstants[m.pagination]; ok {
+			fmt.Fprintf(&buf, "pagination: %s,\n", c)
+		}
+		if len(m.parentParams) > 0 {
+			buf.WriteString("parentParams: []string{")
+			for i, p := range m.parentParams {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				fmt.Fprintf(&buf, "%q", p)
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// mapping describes the endpoints of a resource type. The fields match those
+// of resourceEndpoint in the generated file.
+type mapping struct {
+	name         string
+	list, get    string
+	scope        string
+	idField      string
+	importID     string
+	pagination   string
+	parentParams []string
+}
+
+// scopeConstants and paginationConstants are the names of the constants the
+// generated file uses for each scope and pagination style.
+var (
+	scopeConstants = map[string]string{
+		"account":         "scopeAccount",
+		"zone":            "scopeZone",
+		"account_or_zone": "scopeAccountOrZone",
+		"user":            "scopeUser",
+	}
+	paginationConstants = map[string]string{
+		"none":   "paginationNone",
+		"page":   "paginationPage",
+		"cursor": "paginationCursor",
+	}
+)
+
+// placeholderPattern matches the path parameters of an endpoint.
+var placeholderPattern = regexp.MustCompile(`{[a-z0-9_]*}`)
+
+// buildMappings describes the endpoints of every resource. spec may be nil.
+func buildMappings(resources []stainlessResource, spec *openAPISpec) []mapping {
+	seen := map[string]bool{}
+	mappings := make([]mapping, 0, len(resources))
+	for _, r := range resources {
+		m := mapping{name: "cloudflare_" + r.terraformName, list: r.list.path, get: r.get.path}
+		if seen[m.name] {
+			continue
+		}
+		seen[m.name] = true
+
+		// resources are fetched from the `list` endpoint where there is one.
+		endpoint, op := m.list, r.list
+		if endpoint == "" {
+			endpoint, op = m.get, r.get
+		}
+
+		var scopeParams []string
+		m.scope, scopeParams = endpointScope(endpoint)
+		m.parentParams = pathParams(endpoint, scopeParams)
+		m.importID = importIDTemplate(m.get)
+		m.idField = idField(m.get, spec)
+		m.pagination = op.pagination
+		if m.pagination == "" {
+			m.pagination = spec.pagination(endpoint)
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings
+}
+
+// endpointScope returns the scope of endpoint and the path parameters that
+// identify the account or zone in it.
+func endpointScope(endpoint string) (string, []string) {
+	segments := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+	switch {
+	case segments[0] == "{accounts_or_zones}" && len(segments) > 1:
+		return "account_or_zone", segments[:2]
+	case segments[0] == "accounts" && len(segments) > 1 && placeholderPattern.MatchString(segments[1]):
+		return "account", segments[1:2]
+	case segments[0] == "zones" && len(segments) > 1 && placeholderPattern.MatchString(segments[1]):
+		return "zone", segments[1:2]
+	case segments[0] == "user":
+		return "user", nil
+	}
+	return "", nil
+}
+
+// pathParams returns the path parameters of endpoint, except for exclude.
+func pathParams(endpoint string, exclude []string) []string {
+	var params []string
+	for _, p := range placeholderPattern.FindAllString(endpoint, -1) {
+		if !slices.Contains(exclude, p) {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// importIDTemplate returns the import ID of a resource with the `get`
+// endpoint get. `{id}` is the ID of the resource, and `{accounts_or_zones}` and
+// `{account_or_zone_id}` are the scope of endpoints that serve both accounts
+// and zones. By convention the first path parameter is the account or zone
+// and the second is the resource, so this won't match un-RESTful routes.
+func importIDTemplate(get string) string {
+	combined := strings.Contains(get, "{accounts_or_zones}")
+	var params []string
+	for _, p := range placeholderPattern.FindAllString(get, -1) {
+		if p != "{accounts_or_zones}" {
+			params = append(params, p)
+		}
+	}
+
+	switch len(params) {
+	case 0:
+		return ""
+	case 1:
+		params[0] = "{id}"
+	default:
+		params[1] = "{id}"
+	}
+
+	template := strings.Join(params, "/")
+	if combined {
+		template = "{accounts_or_zones}/" + template
+	}
+	return template
+}
+
+// idField returns the field of the API response that identifies a resource
+// with the `get` endpoint get, going by the last path parameter of the
+// endpoint and, when spec is set, the properties of the response. An empty
+// string is returned for resources there is only one of per account or zone.
+func idField(get string, spec *openAPISpec) string {
+	_, scopeParams := endpointScope(get)
+	params := pathParams(get, scopeParams)
+	if len(params) == 0 {
+		return ""
+	}
+
+	param := strings.Trim(params[len(params)-1], "{}")
+	props := spec.resultProperties(get)
+	if len(props) == 0 {
+		return "id"
+	}
+	candidates := []string{param}
+	if i := strings.LastIndex(param, "_"); i >= 0 {
+		candidates = append(candidates, param[i+1:])
+	}
+	candidates = append(candidates, "id")
+	for _, c := range candidates {
+		if props[c] {
+			return c
+		}
+	}
+	return "id"
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMappings(t *testing.T) {
+	resources, err := readStainlessConfig("testdata/stainless.yml")
+	require.NoError(t, err)
+	spec, err := readOpenAPISpec("testdata/openapi.yml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []mapping{
+		{
+			name: "cloudflare_zone", list: "/zones", get: "/zones/{zone_id}",
+			importID: "{id}", pagination: "page",
+		},
+		{
+			name: "cloudflare_zone_setting", get: "/zones/{zone_id}/settings/{setting_id}",
+			scope: "zone", idField: "id", importID: "{zone_id}/{id}", pagination: "none",
+			parentParams: []string{"{setting_id}"},
+		},
+		{
+			name: "cloudflare_dns_record", list: "/zones/{zone_id}/dns_records", get: "/zones/{zone_id}/dns_records/{dns_record_id}",
+			scope: "zone", idField: "id", importID: "{zone_id}/{id}", pagination: "page",
+		},
+		{
+			name: "cloudflare_list", list: "/accounts/{account_id}/rules/lists", get: "/accounts/{account_id}/rules/lists/{list_id}",
+			scope: "account", idField: "id", importID: "{account_id}/{id}",
+		},
+		{
+			name: "cloudflare_list_item", list: "/accounts/{account_id}/rules/lists/{list_id}/items", get: "/accounts/{account_id}/rules/lists/{list_id}/items/{item_id}",
+			scope: "account", idField: "id", importID: "{account_id}/{id}/{item_id}", pagination: "cursor",
+			parentParams: []string{"{list_id}"},
+		},
+		{
+			name: "cloudflare_ruleset", list: "/{accounts_or_zones}/{account_or_zone_id}/rulesets", get: "/{accounts_or_zones}/{account_or_zone_id}/rulesets/{ruleset_id}",
+			scope: "account_or_zone", idField: "id", importID: "{accounts_or_zones}/{account_or_zone_id}/{id}", pagination: "none",
+		},
+		{
+			name: "cloudflare_r2_bucket", list: "/accounts/{account_id}/r2/buckets", get: "/accounts/{account_id}/r2/buckets/{bucket_name}",
+			scope: "account", idField: "name", importID: "{account_id}/{id}", pagination: "cursor",
+		},
+		{
+			name: "cloudflare_user", get: "/user", scope: "user",
+		},
+		{
+			// only GET requests are used.
+			name: "cloudflare_purge",
+		},
+	}, buildMappings(resources, spec))
+
+	// without the OpenAPI specification, pagination is only known when the
+	// configuration names a scheme.
+	mappings := buildMappings(resources, nil)
+	assert.Equal(t, "", mappings[0].pagination)
+	assert.Equal(t, "page", mappings[2].pagination)
+	assert.Equal(t, "id", mappings[6].idField)
+}
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "resource_to_endpoint_mapping.go")
+	require.NoError(t, run("testdata/stainless.yml", "testdata/openapi.yml", output, "generator"))
+
+	src, err := os.ReadFile(output)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), output, src, 0)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "// Code generated by internal/tools/endpointmapping. DO NOT EDIT.")
+	assert.Contains(t, string(src), `	"cloudflare_list_item": {
+		list:         "/accounts/{account_id}/rules/lists/{list_id}/items",
+		get:          "/accounts/{account_id}/rules/lists/{list_id}/items/{item_id}",
+		scope:        scopeAccount,
+		idField:      "id",
+		importID:     "{account_id}/{id}/{item_id}",
+		pagination:   paginationCursor,
+		parentParams: []string{"{list_id}"},
+	},`)
+
+	assert.ErrorContains(t, run("", "", output, "generator"), "-config is required")
+	assert.ErrorContains(t, run("testdata/missing.yml", "", output, "generator"), "failed to read Stainless configuration")
+}
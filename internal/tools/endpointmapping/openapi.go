@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISpec is the part of an OpenAPI specification that is needed to
+// describe the endpoints of a resource.
+type openAPISpec struct {
+	Paths      map[string]map[string]openAPIOperation `yaml:"paths"`
+	Components struct {
+		Schemas    map[string]*openAPISchema    `yaml:"schemas"`
+		Parameters map[string]*openAPIParameter `yaml:"parameters"`
+	} `yaml:"components"`
+}
+
+type openAPIOperation struct {
+	Parameters []*openAPIParameter `yaml:"parameters"`
+	Responses  map[string]struct {
+		Content map[string]struct {
+			Schema *openAPISchema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref  string `yaml:"$ref"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Items      *openAPISchema            `yaml:"items"`
+	AllOf      []*openAPISchema          `yaml:"allOf"`
+}
+
+// readOpenAPISpec reads the OpenAPI specification at path, which is either
+// JSON or YAML.
+func readOpenAPISpec(path string) (*openAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI specification: %w", err)
+	}
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI specification: %w", err)
+	}
+	return &spec, nil
+}
+
+// operation returns the GET operation of path, if it is in the specification.
+func (s *openAPISpec) operation(path string) (openAPIOperation, bool) {
+	if s == nil || path == "" {
+		return openAPIOperation{}, false
+	}
+	op, ok := s.Paths[path]["get"]
+	return op, ok
+}
+
+// pagination returns the pagination style of the GET operation of path going
+// by its query parameters, or an empty string when it isn't known.
+func (s *openAPISpec) pagination(path string) string {
+	op, ok := s.operation(path)
+	if !ok {
+		return ""
+	}
+	query := map[string]bool{}
+	for _, p := range op.Parameters {
+		if p = s.resolveParameter(p); p != nil && p.In == "query" {
+			query[p.Name] = true
+		}
+	}
+	switch {
+	case query["cursor"]:
+		return "cursor"
+	case query["page"]:
+		return "page"
+	default:
+		return "none"
+	}
+}
+
+// resultProperties returns the properties of a single resource in the
+// `result` of the response of the GET operation of path.
+func (s *openAPISpec) resultProperties(path string) map[string]bool {
+	op, ok := s.operation(path)
+	if !ok {
+		return nil
+	}
+	response, ok := op.Responses["200"]
+	if !ok {
+		return nil
+	}
+	media, ok := response.Content["application/json"]
+	if !ok {
+		return nil
+	}
+	result := s.properties(media.Schema, 0)["result"]
+	if result = s.resolveSchema(result, 0); result != nil && result.Items != nil {
+		result = result.Items
+	}
+	props := map[string]bool{}
+	for name := range s.properties(result, 0) {
+		props[name] = true
+	}
+	return props
+}
+
+// maxSchemaDepth stops following references of recursive schemas.
+const maxSchemaDepth = 32
+
+// properties returns the properties of schema, merging those of allOf.
+func (s *openAPISpec) properties(schema *openAPISchema, depth int) map[string]*openAPISchema {
+	schema = s.resolveSchema(schema, depth)
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+	props := map[string]*openAPISchema{}
+	for _, sub := range schema.AllOf {
+		for name, prop := range s.properties(sub, depth+1) {
+			props[name] = prop
+		}
+	}
+	for name, prop := range schema.Properties {
+		props[name] = prop
+	}
+	return props
+}
+
+// resolveSchema follows the reference of schema to the components, if any.
+func (s *openAPISpec) resolveSchema(schema *openAPISchema, depth int) *openAPISchema {
+	for schema != nil && schema.Ref != "" && depth <= maxSchemaDepth {
+		schema = s.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		depth++
+	}
+	return schema
+}
+
+// resolveParameter follows the reference of p to the components, if any.
+func (s *openAPISpec) resolveParameter(p *openAPIParameter) *openAPIParameter {
+	if p != nil && p.Ref != "" {
+		return s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+	}
+	return p
+}
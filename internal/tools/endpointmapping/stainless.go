@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// skippedResources are Terraform resource names, without the `cloudflare_`
+// prefix, that are left out of the mapping.
+var skippedResources = map[string]bool{
+	// deprecated and backend service support automatic migrations
+	"firewall_rule":   true,
+	"firewall_filter": true,
+
+	// doesn't work with outer wrap parameters
+	"cloud_connector_rules": true,
+
+	// terraform can't get the content (may be bundled, etc)
+	"workers_script": true,
+}
+
+// stainlessResource is a resource of the Stainless configuration that is a
+// Terraform resource.
+type stainlessResource struct {
+	terraformName string
+	list, get     operation
+}
+
+// operation is the `list` or `get` method of a resource.
+type operation struct {
+	path string
+	// pagination is the style named in the configuration, if any.
+	pagination string
+}
+
+// readStainlessConfig returns the Terraform resources of the Stainless
+// configuration at path, in the order they are defined with subresources
+// following their parent.
+func readStainlessConfig(path string) ([]stainlessResource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Stainless configuration: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Stainless configuration: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	root := doc.Content[0]
+
+	resources := lookup(root, "resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s doesn't define any resources", path)
+	}
+
+	styles := paginationStyles(lookup(root, "pagination"))
+	var out []stainlessResource
+	for i := 1; i < len(resources.Content); i += 2 {
+		out = appendResource(out, resources.Content[i], styles)
+	}
+	return out, nil
+}
+
+// appendResource appends the resource defined by node, and its subresources,
+// to resources if they are Terraform resources.
+func appendResource(resources []stainlessResource, node *yaml.Node, styles map[string]string) []stainlessResource {
+	if name := terraformName(node); name != "" && !skippedResources[name] {
+		if list, get, ok := operations(node, styles); ok {
+			resources = append(resources, stainlessResource{terraformName: name, list: list, get: get})
+		}
+	}
+
+	subresources := lookup(node, "subresources")
+	if subresources == nil || subresources.Kind != yaml.MappingNode {
+		return resources
+	}
+	for i := 1; i < len(subresources.Content); i += 2 {
+		resources = appendResource(resources, subresources.Content[i], styles)
+	}
+	return resources
+}
+
+// terraformName returns the name of the Terraform resource of a resource, or
+// an empty string when it has none or it has been disabled.
+func terraformName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.MappingNode:
+		tf := lookup(node, "terraform")
+		if tf == nil || tf.Kind != yaml.MappingNode {
+			// also covers `terraform: false`
+			return ""
+		}
+		if r := lookup(tf, "resource"); r != nil && r.Value == "false" {
+			return ""
+		}
+		if name := lookup(tf, "name"); name != nil {
+			return name.Value
+		}
+	}
+	return ""
+}
+
+// operations returns the `list` and `get` methods of a resource. Only GET
+// requests are used, a resource with other methods is mapped without
+// endpoints. ok is false when the resource is left out entirely.
+func operations(node *yaml.Node, styles map[string]string) (list, get operation, ok bool) {
+	if node.Kind != yaml.MappingNode {
+		return operation{}, operation{}, true
+	}
+	methods := lookup(node, "methods")
+	listNode, getNode := lookup(methods, "list"), lookup(methods, "get")
+
+	var isGET bool
+	if list, isGET = parseOperation(listNode, styles); !isGET {
+		return operation{}, operation{}, true
+	}
+	if get, isGET = parseOperation(getNode, styles); !isGET {
+		// a `get` method that isn't a GET request has no usable endpoint.
+		if getNode.Kind == yaml.MappingNode {
+			return operation{}, operation{}, false
+		}
+		return operation{}, operation{}, true
+	}
+	if get.path == "" {
+		get.path = list.path
+	}
+	return list, get, true
+}
+
+// parseOperation returns the method defined by node, which is either an
+// endpoint such as `get /zones` or a mapping with an `endpoint`. ok is false
+// when the endpoint isn't a GET request.
+func parseOperation(node *yaml.Node, styles map[string]string) (op operation, ok bool) {
+	if node == nil {
+		return operation{}, true
+	}
+
+	endpoint := node.Value
+	if node.Kind == yaml.MappingNode {
+		if e := lookup(node, "endpoint"); e != nil {
+			endpoint = e.Value
+		}
+		if p := lookup(node, "paginated"); p != nil && p.Value == "false" {
+			op.pagination = "none"
+		}
+		if p := lookup(node, "pagination"); p != nil {
+			op.pagination = styles[p.Value]
+		}
+	}
+
+	path, ok := strings.CutPrefix(endpoint, "get ")
+	if !ok {
+		return operation{}, false
+	}
+	op.path = strings.TrimSpace(path)
+	return op, true
+}
+
+// paginationStyles returns the style of each pagination scheme defined in the
+// top level `pagination` list, by name.
+func paginationStyles(node *yaml.Node) map[string]string {
+	styles := map[string]string{}
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return styles
+	}
+	for _, scheme := range node.Content {
+		name, typ := lookup(scheme, "name"), lookup(scheme, "type")
+		if name == nil || typ == nil {
+			continue
+		}
+		switch {
+		case typ.Value == "page_number":
+			styles[name.Value] = "page"
+		case strings.HasPrefix(typ.Value, "cursor"):
+			styles[name.Value] = "cursor"
+		}
+	}
+	return styles
+}
+
+// lookup returns the value of key in the mapping node, or nil.
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
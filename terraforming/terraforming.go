@@ -0,0 +1,199 @@
+// Package terraforming generates Terraform configuration and import blocks for
+// existing Cloudflare resources. It is the library behind the cf-terraforming
+// command line tool and can be used to embed generation in other programs:
+//
+//	f, imports, err := terraforming.Generate(ctx, terraforming.Options{
+//		APIToken:        os.Getenv("CLOUDFLARE_API_TOKEN"),
+//		ZoneID:          "0da42c8d2132a9ddaf714f9e7c920711",
+//		ResourceTypes:   []string{"cloudflare_dns_record"},
+//		ProviderVersion: version,
+//		Schema:          schema,
+//	})
+//
+// The provider version and schema are those reported by `terraform version
+// -json` and `terraform providers schema -json` for the Cloudflare provider.
+package terraforming
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudflare/cf-terraforming/internal/app/cf-terraforming/generator"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+)
+
+// Options configure a single call to Generate.
+type Options struct {
+	// APIToken authenticates the requests to the Cloudflare API. Either it,
+	// both APIKey and APIEmail, or OriginCAKey must be set.
+	APIToken string
+	APIKey   string
+	APIEmail string
+
+	// OriginCAKey is used for the Origin CA certificate endpoints. It is
+	// enough on its own to generate cloudflare_origin_ca_certificate.
+	OriginCAKey string
+
+	// AccountID or ZoneID is the scope resources are generated for. Exactly
+	// one of them must be set.
+	AccountID string
+	ZoneID    string
+
+	// ResourceTypes are the resource types to generate, such as
+	// cloudflare_dns_record.
+	ResourceTypes []string
+
+	// ResourceIDs are the parent IDs of resource types that are fetched per
+	// parent, such as the settings of cloudflare_zone_setting. It is the
+	// equivalent of `--resource-id`.
+	ResourceIDs map[string][]string
+
+	// ProviderVersion and Schema describe the Cloudflare provider the
+	// configuration is generated for, as reported by `terraform version
+	// -json` and `terraform providers schema -json`.
+	ProviderVersion string
+	Schema          *tfjson.ProviderSchema
+
+	// HTTPClient sends the API requests. http.DefaultClient is used when it
+	// is nil.
+	HTTPClient *http.Client
+
+	// BaseURL replaces the public Cloudflare API, e.g. for a mock server or
+	// a proxy. It has the same format as `--api-base-url`.
+	BaseURL string
+
+	// StubDir is the directory placeholder files are created in for values
+	// the API doesn't return, such as private keys. It defaults to the
+	// working directory.
+	StubDir string
+
+	// Logger receives diagnostics and notices, such as resource types
+	// without any resources. The standard logger of logrus is used when it
+	// is nil.
+	Logger *logrus.Logger
+}
+
+// ImportBlock is a Terraform `import` block for a generated resource. Its To
+// field is the address of the resource and ID its import ID.
+type ImportBlock = generator.ImportBlock
+
+// validate checks that the options are complete.
+func (o Options) validate() error {
+	switch {
+	case o.AccountID != "" && o.ZoneID != "":
+		return errors.New("AccountID and ZoneID are mutually exclusive")
+	case o.AccountID == "" && o.ZoneID == "":
+		return errors.New("either AccountID or ZoneID must be set")
+	case o.APIToken == "" && (o.APIKey == "" || o.APIEmail == "") && o.OriginCAKey == "":
+		return errors.New("either APIToken, both APIKey and APIEmail, or OriginCAKey must be set")
+	case len(o.ResourceTypes) == 0:
+		return errors.New("at least one resource type must be set")
+	case o.Schema == nil || o.ProviderVersion == "":
+		return errors.New("ProviderVersion and Schema must be set")
+	}
+	for _, rType := range o.ResourceTypes {
+		if _, ok := generator.Endpoint(rType); !ok {
+			return fmt.Errorf("%s is not a supported resource type", rType)
+		}
+	}
+	return nil
+}
+
+// Generate fetches the resources of every resource type in opts and returns
+// the generated configuration and the import blocks for the resources in it.
+// Resource types that fail don't stop the others from being generated; their
+// errors are joined in the returned error alongside the configuration of the
+// others.
+func Generate(ctx context.Context, opts Options) (*hclwrite.File, []ImportBlock, error) {
+	if err := opts.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	httpClient := http.DefaultClient
+	if opts.HTTPClient != nil {
+		httpClient = opts.HTTPClient
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	g, err := generator.New(generator.Options{
+		APIToken:        opts.APIToken,
+		APIKey:          opts.APIKey,
+		APIEmail:        opts.APIEmail,
+		OriginCAKey:     opts.OriginCAKey,
+		AccountID:       opts.AccountID,
+		ZoneID:          opts.ZoneID,
+		ResourceIDs:     opts.ResourceIDs,
+		ProviderVersion: opts.ProviderVersion,
+		HTTPClient:      withContext(ctx, httpClient),
+		BaseURL:         opts.BaseURL,
+		StubDir:         opts.StubDir,
+		Logger:          logger,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out, notices bytes.Buffer
+	var imports []ImportBlock
+	var failures []error
+	for _, rType := range opts.ResourceTypes {
+		if err := ctx.Err(); err != nil {
+			failures = append(failures, err)
+			break
+		}
+
+		typeImports, err := g.Generate(ctx, &out, &notices, opts.Schema, []string{rType})
+		imports = append(imports, typeImports...)
+		if err != nil {
+			failures = append(failures, err)
+		}
+		if notices.Len() > 0 {
+			logger.WithField("resource", rType).Info(strings.TrimSpace(notices.String()))
+			notices.Reset()
+		}
+	}
+	err = errors.Join(failures...)
+
+	f, diags := hclwrite.ParseConfig(out.Bytes(), "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, errors.Join(err, diags)
+	}
+	return f, imports, err
+}
+
+// WriteImportBlocks appends an `import` block to body for each of imports.
+func WriteImportBlocks(body *hclwrite.Body, imports []ImportBlock) {
+	generator.WriteImportBlocks(body, imports)
+}
+
+// withContext returns a copy of client whose requests are cancelled once ctx
+// is done.
+func withContext(ctx context.Context, client *http.Client) *http.Client {
+	rt := client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	c := *client
+	c.Transport = &contextTransport{ctx: ctx, rt: rt}
+	return &c
+}
+
+// contextTransport is an http.RoundTripper that sends requests with ctx, so
+// that they are aborted, even while in flight, once ctx is done.
+type contextTransport struct {
+	ctx context.Context
+	rt  http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.rt.RoundTrip(req.WithContext(t.ctx))
+}
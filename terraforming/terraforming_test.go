@@ -0,0 +1,155 @@
+package terraforming
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const testZoneID = "0da42c8d2132a9ddaf714f9e7c920711"
+
+// dnsRecordSchema is a minimal provider schema for cloudflare_dns_record.
+var dnsRecordSchema = &tfjson.ProviderSchema{
+	ResourceSchemas: map[string]*tfjson.Schema{
+		"cloudflare_dns_record": {Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"id":      {AttributeType: cty.String, Computed: true},
+				"zone_id": {AttributeType: cty.String, Required: true},
+				"name":    {AttributeType: cty.String, Required: true},
+				"type":    {AttributeType: cty.String, Required: true},
+				"content": {AttributeType: cty.String, Optional: true},
+			},
+		}},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	t.Setenv("USE_STATIC_RESOURCE_IDS", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/client/v4/zones/" + testZoneID + "/dns_records":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[{"id":"abc","name":"example.com","type":"A","content":"192.0.2.1"}]}`))
+		case "/client/v4/zones/" + testZoneID + "/email/routing/rules":
+			_, _ = w.Write([]byte(`{"success":true,"errors":[],"messages":[],"result":[]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}],"messages":[],"result":null}`))
+		}
+	}))
+	defer server.Close()
+
+	opts := Options{
+		APIToken:        "test-token",
+		ZoneID:          testZoneID,
+		ResourceTypes:   []string{"cloudflare_dns_record"},
+		ProviderVersion: "5.0.0",
+		Schema:          dnsRecordSchema,
+		HTTPClient:      server.Client(),
+		BaseURL:         server.URL + "/client/v4",
+	}
+
+	f, imports, err := Generate(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, `resource "cloudflare_dns_record" "terraform_managed_resource_abc_0" {
+  content = "192.0.2.1"
+  name    = "example.com"
+  type    = "A"
+  zone_id = "`+testZoneID+`"
+}
+
+`, string(f.Bytes()))
+	assert.Equal(t, []ImportBlock{
+		{To: "cloudflare_dns_record.terraform_managed_resource_abc_0", ID: testZoneID + "/abc"},
+	}, imports)
+
+	body := hclwrite.NewEmptyFile()
+	WriteImportBlocks(body.Body(), imports)
+	assert.Equal(t, `import {
+  to = cloudflare_dns_record.terraform_managed_resource_abc_0
+  id = "`+testZoneID+`/abc"
+}
+
+`, string(body.Bytes()))
+
+	t.Run("failing resource types", func(t *testing.T) {
+		opts := opts
+		opts.ResourceTypes = []string{"cloudflare_page_rule", "cloudflare_dns_record"}
+		f, imports, err := Generate(context.Background(), opts)
+		assert.ErrorContains(t, err, "cloudflare_page_rule")
+		assert.Contains(t, string(f.Bytes()), `resource "cloudflare_dns_record"`)
+		assert.Len(t, imports, 1)
+	})
+
+	t.Run("notices go to the logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := logrus.New()
+		logger.SetOutput(&logs)
+
+		opts := opts
+		opts.ResourceTypes = []string{"cloudflare_email_routing_rule", "cloudflare_dns_record"}
+		opts.Logger = logger
+		_, imports, err := Generate(context.Background(), opts)
+		require.NoError(t, err)
+		assert.Len(t, imports, 1)
+		assert.Contains(t, logs.String(), `no resources of type \"cloudflare_email_routing_rule\" found to generate`)
+		assert.Contains(t, logs.String(), "resource=cloudflare_email_routing_rule")
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := Generate(ctx, opts)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("cancelled while in flight", func(t *testing.T) {
+		var once sync.Once
+		received := make(chan struct{})
+		blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			once.Do(func() { close(received) })
+			<-r.Context().Done()
+		}))
+		defer blocking.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-received
+			cancel()
+		}()
+		opts := opts
+		opts.HTTPClient = blocking.Client()
+		opts.BaseURL = blocking.URL + "/client/v4"
+		_, _, err := Generate(ctx, opts)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, tc := range []struct {
+			modify func(*Options)
+			err    string
+		}{
+			{func(o *Options) { o.AccountID = "a" }, "mutually exclusive"},
+			{func(o *Options) { o.ZoneID = "" }, "either AccountID or ZoneID"},
+			{func(o *Options) { o.APIToken = "" }, "either APIToken, both APIKey and APIEmail, or OriginCAKey"},
+			{func(o *Options) { o.Schema = nil }, "ProviderVersion and Schema"},
+			{func(o *Options) { o.ResourceTypes = []string{"cloudflare_not_real"} }, "cloudflare_not_real is not a supported resource type"},
+		} {
+			opts := opts
+			tc.modify(&opts)
+			_, _, err := Generate(context.Background(), opts)
+			assert.ErrorContains(t, err, tc.err)
+		}
+	})
+}
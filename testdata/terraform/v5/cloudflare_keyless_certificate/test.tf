@@ -1,5 +1,5 @@
 resource "cloudflare_keyless_certificate" "terraform_managed_resource" {
-  certificate = "-----INSERT CERTIFICATE-----"
+  certificate = file("${path.module}/certs/cloudflare_keyless_certificate_ebe3d64c-64b7-4c49-9f29-ca9c222aa814.pem")
   enabled     = false
   host        = "terraform.cfapi.net"
   name        = "ydvgqcbbcq"
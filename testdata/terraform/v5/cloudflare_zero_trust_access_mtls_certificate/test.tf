@@ -1,7 +1,7 @@
 resource "cloudflare_zero_trust_access_mtls_certificate" "terraform_managed_resource" {
   account_id           = "f037e56e89293a057740de681ac9abbe"
   associated_hostnames = []
-  certificate          = "-----INSERT CERTIFICATE-----"
+  certificate          = file("${path.module}/certs/cloudflare_zero_trust_access_mtls_certificate_63b92c05-8635-4a44-ae8d-45236af35d00.pem")
   name                 = "Allow devs"
 }
 